@@ -1,11 +1,139 @@
 package dialect
 
+// Dialect represents the syntax rules of a RDBMS.
+// The tokenizer consults the identifier rules and keywords, and the parser consults the reserved words and features.
 type Dialect interface {
 	IsIdentifierStart(r rune) bool
 	IsIdentifierPart(r rune) bool
 	IsDelimitedIdentifierStart(r rune) bool
+
+	// Keywords returns the keywords of the dialect in upper case.
+	// The tokenizer leaves SQLWord.Keyword empty for the other words.
+	Keywords() map[string]struct{}
+	// ReservedForTableAlias returns the keywords which cannot be a table alias without AS.
+	ReservedForTableAlias() map[string]struct{}
+	// ReservedForColumnAlias returns the keywords which cannot be a column alias without AS.
+	ReservedForColumnAlias() map[string]struct{}
+	// Supports reports whether the dialect accepts the syntax of f.
+	Supports(f Feature) bool
 }
 
+// Feature is a syntax which only some dialects accept.
+type Feature int
+
+const (
+	// INSERT ... ON DUPLICATE KEY UPDATE
+	OnDuplicateKeyUpdate Feature = iota
+	// expr::type
+	DoubleColonCast
+	// INT UNSIGNED
+	UnsignedInteger
+	// AUTO_INCREMENT or AUTOINCREMENT after a column definition
+	AutoIncrement
+	// ENGINE = ..., DEFAULT CHARSET = ... after CREATE TABLE
+	TableOptions
+	// CREATE VIRTUAL TABLE ... USING module(...)
+	VirtualTable
+	// FROM t WITH (hints)
+	TableHints
+	// INSERT OR REPLACE/IGNORE/ABORT/FAIL/ROLLBACK INTO ...
+	InsertOr
+	// REPLACE INTO ...
+	ReplaceInto
+	// PRAGMA name [= value]
+	Pragma
+	// ATTACH DATABASE ... AS name, DETACH DATABASE name
+	AttachDatabase
+	// WITHOUT ROWID or STRICT after CREATE TABLE
+	WithoutRowID
+	// SELECT TOP (n) [PERCENT] [WITH TIES]
+	Top
+	// CROSS APPLY and OUTER APPLY
+	Apply
+	// OUTPUT inserted.* of INSERT, UPDATE and DELETE
+	OutputClause
+	// JSON access operators -> and ->>
+	JSONOperators
+	// jsonb and array operators #>, #>>, @>, <@, ?, ?|, ?& and &&
+	JSONBOperators
+	// POSIX regular expression operators ~, ~*, !~ and !~*
+	RegexOperators
+	// bitwise XOR operator a # b
+	HashXor
+	// NULL-safe equal operator <=>
+	NullSafeEqual
+	// case-insensitive pattern match ILIKE
+	ILike
+	// SIMILAR TO pattern match
+	SimilarTo
+	// REGEXP and RLIKE pattern match
+	RegexpLike
+	// GROUP BY ROLLUP (...), CUBE (...) and GROUPING SETS (...)
+	GroupingSets
+	// GROUP BY ... WITH ROLLUP
+	WithRollup
+	// LIMIT offset, count
+	LimitComma
+	// OFFSET n ROWS FETCH { FIRST | NEXT } n ROWS { ONLY | WITH TIES }
+	OffsetFetch
+	// ORDER BY expr USING operator
+	OrderByUsing
+	// FOR UPDATE / FOR SHARE [OF tables] [NOWAIT | SKIP LOCKED]
+	LockingClause
+	// LOCK IN SHARE MODE
+	LockInShareMode
+	// SELECT DISTINCT ON (...)
+	DistinctOn
+	// WITH name AS [NOT] MATERIALIZED (...)
+	CTEMaterialized
+	// SEARCH and CYCLE clauses of recursive CTEs
+	CTESearchCycle
+	// INSERT, UPDATE and DELETE in WITH like WITH name AS (DELETE ...)
+	DataModifyingCTE
+	// WITH ORDINALITY, ROWS FROM (...) and column definition lists of functions in FROM
+	TableFunctions
+	// TABLESAMPLE method (...) [REPEATABLE (...)]
+	TableSample
+	// FOR SYSTEM_TIME of temporal tables
+	SystemTime
+	// INSERT ... ON CONFLICT ... DO NOTHING | DO UPDATE
+	OnConflict
+	// RETURNING of INSERT, UPDATE and DELETE
+	Returning
+	// INSERT INTO t DEFAULT VALUES
+	DefaultValues
+	// INSERT IGNORE INTO
+	InsertIgnore
+	// INSERT INTO t SET a = 1
+	InsertSet
+	// array constructor ARRAY[a, b]
+	ArrayConstructor
+	// string concatenation a || b
+	ConcatOperator
+	// a || b as a synonym of a OR b
+	PipesAsOr
+	// bitwise XOR a ^ b
+	CaretXor
+	// exponentiation a ^ b
+	CaretExponent
+	// |, & and shifts have their own precedence levels above comparisons
+	// rather than sharing the level of any other operator
+	BitwisePrecedence
+	// numbered placeholder ?NNN
+	NumberedQuestionPlaceholder
+	// named placeholders @name and $name
+	NamedPlaceholder
+	// SET (a, b) = (1, 2) of UPDATE and ON CONFLICT DO UPDATE
+	MultiColumnAssignment
+	// SEPARATOR 'sep' of GROUP_CONCAT
+	GroupConcatSeparator
+	// FILTER (WHERE ...) of aggregate functions
+	AggregateFilter
+	// escape string constant E'...'
+	EscapeString
+)
+
+// GenericSQLDialect accepts the syntax of all dialects.
 type GenericSQLDialect struct {
 }
 
@@ -21,4 +149,43 @@ func (*GenericSQLDialect) IsDelimitedIdentifierStart(r rune) bool {
 	return r == '"'
 }
 
+func (*GenericSQLDialect) Keywords() map[string]struct{} {
+	return Keywords
+}
+
+func (*GenericSQLDialect) ReservedForTableAlias() map[string]struct{} {
+	return ReservedForTableAlias
+}
+
+func (*GenericSQLDialect) ReservedForColumnAlias() map[string]struct{} {
+	return ReservedForColumnAlias
+}
+
+func (*GenericSQLDialect) Supports(f Feature) bool {
+	return true
+}
+
 var _ Dialect = &GenericSQLDialect{}
+
+// union returns a new keyword set which has all keywords of tables.
+func union(tables ...map[string]struct{}) map[string]struct{} {
+	m := make(map[string]struct{})
+	for _, t := range tables {
+		for k := range t {
+			m[k] = struct{}{}
+		}
+	}
+	return m
+}
+
+// extend returns a new keyword set which has all keywords of base and extra.
+func extend(base map[string]struct{}, extra ...string) map[string]struct{} {
+	m := make(map[string]struct{}, len(base)+len(extra))
+	for k := range base {
+		m[k] = struct{}{}
+	}
+	for _, k := range extra {
+		m[k] = struct{}{}
+	}
+	return m
+}
@@ -1,9 +1,28 @@
 package dialect
 
+// keywords of generic sql
 var Keywords map[string]struct{}
 var ReservedForTableAlias map[string]struct{}
 var ReservedForColumnAlias map[string]struct{}
 
+// keywords and reserved words shared by all dialects
+var keywords map[string]struct{}
+var reservedForTableAlias map[string]struct{}
+var reservedForColumnAlias map[string]struct{}
+
+// dialect specific keywords
+var myKeywords map[string]struct{}
+var myReservedForTableAlias map[string]struct{}
+var pgKeywords map[string]struct{}
+var pgReservedForTableAlias map[string]struct{}
+var pgReservedForColumnAlias map[string]struct{}
+var liteKeywords map[string]struct{}
+var liteReservedForTableAlias map[string]struct{}
+var liteReservedForColumnAlias map[string]struct{}
+var msKeywords map[string]struct{}
+var msReservedForTableAlias map[string]struct{}
+var msReservedForColumnAlias map[string]struct{}
+
 func init() {
 	Keywords = make(map[string]struct{})
 	Keywords[ABS] = struct{}{}
@@ -178,6 +197,7 @@ func init() {
 	Keywords[LOCALTIME] = struct{}{}
 	Keywords[LOCALTIMESTAMP] = struct{}{}
 	Keywords[LOCATION] = struct{}{}
+	Keywords[LOCK] = struct{}{}
 	Keywords[LOWER] = struct{}{}
 	Keywords[MATCH] = struct{}{}
 	Keywords[MATERIALIZED] = struct{}{}
@@ -353,6 +373,11 @@ func init() {
 	Keywords[WITHOUT] = struct{}{}
 	Keywords[YEAR] = struct{}{}
 	Keywords[ZONE] = struct{}{}
+	for _, k := range []string{"ACTION", "CASCADE", "DATABASE", "EXCLUDE", "EXPLAIN", "FIRST", "IF", "INDEX", "LAST",
+		"LOCKED", "MODE", "NEXT", "NOWAIT", "NULLS", "OTHERS", "PLACING", "REPEATABLE", "RESTRICT", "SETS", "SHARE",
+		"SKIP", "TIES", "TYPE"} {
+		Keywords[k] = struct{}{}
+	}
 
 	ReservedForTableAlias = make(map[string]struct{})
 	ReservedForTableAlias[WITH] = struct{}{}
@@ -370,8 +395,13 @@ func init() {
 	ReservedForTableAlias[FULL] = struct{}{}
 	ReservedForTableAlias[LEFT] = struct{}{}
 	ReservedForTableAlias[RIGHT] = struct{}{}
+	ReservedForTableAlias[OUTER] = struct{}{}
 	ReservedForTableAlias[NATURAL] = struct{}{}
 	ReservedForTableAlias[USING] = struct{}{}
+	ReservedForTableAlias[WINDOW] = struct{}{}
+	ReservedForTableAlias[LIMIT] = struct{}{}
+	ReservedForTableAlias[FOR] = struct{}{}
+	ReservedForTableAlias[LOCK] = struct{}{}
 
 	ReservedForColumnAlias = make(map[string]struct{})
 	ReservedForColumnAlias[WITH] = struct{}{}
@@ -383,6 +413,41 @@ func init() {
 	ReservedForColumnAlias[EXCEPT] = struct{}{}
 	ReservedForColumnAlias[INTERSECT] = struct{}{}
 	ReservedForColumnAlias[FROM] = struct{}{}
+	ReservedForColumnAlias[INTO] = struct{}{}
+	ReservedForColumnAlias[VALUES] = struct{}{}
+	ReservedForColumnAlias[LIMIT] = struct{}{}
+	ReservedForColumnAlias[FOR] = struct{}{}
+
+	// the words which start a clause of only some dialects are reserved by those dialects.
+	// GenericSQLDialect accepts the syntax of all dialects so it reserves all of them.
+	reservedForTableAlias = ReservedForTableAlias
+	reservedForColumnAlias = ReservedForColumnAlias
+	ReservedForTableAlias = extend(reservedForTableAlias, OFFSET, FETCH, TABLESAMPLE, "RETURNING")
+	ReservedForColumnAlias = extend(reservedForColumnAlias, OFFSET, FETCH, "RETURNING")
+
+	// the keywords of only some dialects are words of the others,
+	// and GenericSQLDialect has the keywords of all dialects.
+	keywords = Keywords
+
+	myKeywords = extend(keywords, "AUTO_INCREMENT", "CHARSET", "DUPLICATE", "ENGINE", "IGNORE", "REGEXP", "REPLACE", "RLIKE",
+		"SEPARATOR", "STRAIGHT_JOIN", "UNSIGNED",
+		"QUARTER", "WEEK", "MICROSECOND", "YEAR_MONTH", "DAY_HOUR", "DAY_MINUTE", "DAY_SECOND", "DAY_MICROSECOND",
+		"HOUR_MINUTE", "HOUR_SECOND", "HOUR_MICROSECOND", "MINUTE_SECOND", "MINUTE_MICROSECOND", "SECOND_MICROSECOND")
+	myReservedForTableAlias = extend(reservedForTableAlias, "STRAIGHT_JOIN")
+
+	pgKeywords = extend(keywords, "BREADTH", "CONFLICT", "DEPTH", "DO", "ILIKE", "NOTHING", "ORDINALITY", "RETURNING", "SERIAL")
+	pgReservedForTableAlias = extend(reservedForTableAlias, OFFSET, FETCH, TABLESAMPLE, "RETURNING")
+	pgReservedForColumnAlias = extend(reservedForColumnAlias, OFFSET, FETCH, "RETURNING")
+
+	msKeywords = extend(keywords, "APPLY", "CONTAINED", "NOLOCK", "OUTPUT", "PERCENT", "TOP")
+	msReservedForTableAlias = extend(reservedForTableAlias, "APPLY", "OUTPUT", OFFSET, FETCH, TABLESAMPLE)
+	msReservedForColumnAlias = extend(reservedForColumnAlias, OFFSET, FETCH)
+
+	liteKeywords = extend(keywords, "ABORT", "ATTACH", "AUTOINCREMENT", "CONFLICT", "DETACH", "DO", "FAIL", "IGNORE", "NOTHING",
+		"PRAGMA", "REGEXP", "REPLACE", "RETURNING", "ROWID", "STRICT", "WITHOUT")
+	Keywords = union(myKeywords, pgKeywords, msKeywords, liteKeywords)
+	liteReservedForTableAlias = extend(reservedForTableAlias, "RETURNING")
+	liteReservedForColumnAlias = extend(reservedForColumnAlias, "RETURNING")
 }
 
 const (
@@ -558,6 +623,7 @@ const (
 	LOCALTIME                               = "LOCALTIME"
 	LOCALTIMESTAMP                          = "LOCALTIMESTAMP"
 	LOCATION                                = "LOCATION"
+	LOCK                                    = "LOCK"
 	LOWER                                   = "LOWER"
 	MATCH                                   = "MATCH"
 	MATERIALIZED                            = "MATERIALIZED"
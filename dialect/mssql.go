@@ -0,0 +1,40 @@
+package dialect
+
+// MSSQLDialect is the dialect of Microsoft SQL Server (T-SQL).
+type MSSQLDialect struct {
+}
+
+func (*MSSQLDialect) IsIdentifierStart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' || r == '@' || r == '#'
+}
+
+func (*MSSQLDialect) IsIdentifierPart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '@' || r == '#' || r == '$' || r == '_'
+}
+
+func (*MSSQLDialect) IsDelimitedIdentifierStart(r rune) bool {
+	return r == '"' || r == '['
+}
+
+func (*MSSQLDialect) Keywords() map[string]struct{} {
+	return msKeywords
+}
+
+func (*MSSQLDialect) ReservedForTableAlias() map[string]struct{} {
+	return msReservedForTableAlias
+}
+
+func (*MSSQLDialect) ReservedForColumnAlias() map[string]struct{} {
+	return msReservedForColumnAlias
+}
+
+func (*MSSQLDialect) Supports(f Feature) bool {
+	switch f {
+	case TableHints, Top, Apply, OutputClause, GroupingSets, WithRollup, OffsetFetch, TableSample, SystemTime,
+		DefaultValues, CaretXor:
+		return true
+	}
+	return false
+}
+
+var _ Dialect = &MSSQLDialect{}
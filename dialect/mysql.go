@@ -8,4 +8,26 @@ func (*MySQLDialect) IsDelimitedIdentifierStart(r rune) bool {
 	return r == '"' || r == '`'
 }
 
+func (*MySQLDialect) Keywords() map[string]struct{} {
+	return myKeywords
+}
+
+func (*MySQLDialect) ReservedForTableAlias() map[string]struct{} {
+	return myReservedForTableAlias
+}
+
+func (*MySQLDialect) ReservedForColumnAlias() map[string]struct{} {
+	return reservedForColumnAlias
+}
+
+func (*MySQLDialect) Supports(f Feature) bool {
+	switch f {
+	case OnDuplicateKeyUpdate, UnsignedInteger, AutoIncrement, TableOptions, ReplaceInto,
+		JSONOperators, NullSafeEqual, RegexpLike, WithRollup, LimitComma, LockingClause, LockInShareMode,
+		InsertIgnore, InsertSet, PipesAsOr, CaretXor, BitwisePrecedence, GroupConcatSeparator:
+		return true
+	}
+	return false
+}
+
 var _ Dialect = &MySQLDialect{}
@@ -12,7 +12,31 @@ func (*PostgresqlDialect) IsIdentifierPart(r rune) bool {
 }
 
 func (*PostgresqlDialect) IsDelimitedIdentifierStart(r rune) bool {
-	return r == '"' || r == '`'
+	return r == '"'
+}
+
+func (*PostgresqlDialect) Keywords() map[string]struct{} {
+	return pgKeywords
+}
+
+func (*PostgresqlDialect) ReservedForTableAlias() map[string]struct{} {
+	return pgReservedForTableAlias
+}
+
+func (*PostgresqlDialect) ReservedForColumnAlias() map[string]struct{} {
+	return pgReservedForColumnAlias
+}
+
+func (*PostgresqlDialect) Supports(f Feature) bool {
+	switch f {
+	case DoubleColonCast, JSONOperators, JSONBOperators, RegexOperators, HashXor, ILike, SimilarTo,
+		GroupingSets, OffsetFetch, OrderByUsing, LockingClause, DistinctOn, CTEMaterialized, CTESearchCycle,
+		DataModifyingCTE, TableFunctions, TableSample, OnConflict, Returning, DefaultValues,
+		ArrayConstructor, ConcatOperator, CaretExponent, MultiColumnAssignment, AggregateFilter,
+		EscapeString:
+		return true
+	}
+	return false
 }
 
 var _ Dialect = &PostgresqlDialect{}
@@ -0,0 +1,42 @@
+package dialect
+
+type SQLiteDialect struct {
+	GenericSQLDialect
+}
+
+func (*SQLiteDialect) IsIdentifierStart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+}
+
+func (*SQLiteDialect) IsIdentifierPart(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '$' || r == '_'
+}
+
+func (*SQLiteDialect) IsDelimitedIdentifierStart(r rune) bool {
+	return r == '"' || r == '`' || r == '['
+}
+
+func (*SQLiteDialect) Keywords() map[string]struct{} {
+	return liteKeywords
+}
+
+func (*SQLiteDialect) ReservedForTableAlias() map[string]struct{} {
+	return liteReservedForTableAlias
+}
+
+func (*SQLiteDialect) ReservedForColumnAlias() map[string]struct{} {
+	return liteReservedForColumnAlias
+}
+
+func (*SQLiteDialect) Supports(f Feature) bool {
+	switch f {
+	case AutoIncrement, VirtualTable, InsertOr, ReplaceInto, Pragma, AttachDatabase, WithoutRowID,
+		JSONOperators, RegexpLike, LimitComma, CTEMaterialized, OnConflict, Returning,
+		DefaultValues, ConcatOperator, NumberedQuestionPlaceholder, NamedPlaceholder, MultiColumnAssignment,
+		AggregateFilter:
+		return true
+	}
+	return false
+}
+
+var _ Dialect = &SQLiteDialect{}
@@ -15,8 +15,9 @@ import (
 
 func TestInspect(t *testing.T) {
 	cases := []struct {
-		name string
-		dir  string
+		name    string
+		dir     string
+		dialect dialect.Dialect
 	}{
 		{
 			name: "SELECT",
@@ -46,10 +47,29 @@ func TestInspect(t *testing.T) {
 			name: "INSERT",
 			dir:  "insert",
 		},
+		{
+			name:    "SQLite",
+			dir:     "sqlite",
+			dialect: &dialect.SQLiteDialect{},
+		},
+		{
+			name:    "SQL Server",
+			dir:     "mssql",
+			dialect: &dialect.MSSQLDialect{},
+		},
+		{
+			name:    "PostgreSQL",
+			dir:     "postgres",
+			dialect: &dialect.PostgresqlDialect{},
+		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
+			d := c.dialect
+			if d == nil {
+				d = &dialect.GenericSQLDialect{}
+			}
 			fname := fmt.Sprintf("./testdata/%s/", c.dir)
 			files, err := ioutil.ReadDir(fname)
 			if err != nil {
@@ -66,7 +86,7 @@ func TestInspect(t *testing.T) {
 						t.Fatalf("%+v", err)
 					}
 					defer fi.Close()
-					parser, err := xsqlparser.NewParser(fi, &dialect.GenericSQLDialect{})
+					parser, err := xsqlparser.NewParser(fi, d)
 					if err != nil {
 						t.Fatalf("%+v", err)
 					}
@@ -87,8 +107,9 @@ func TestInspect(t *testing.T) {
 
 func TestApply(t *testing.T) {
 	cases := []struct {
-		name string
-		dir  string
+		name    string
+		dir     string
+		dialect dialect.Dialect
 	}{
 		{
 			name: "SELECT",
@@ -118,10 +139,29 @@ func TestApply(t *testing.T) {
 			name: "INSERT",
 			dir:  "insert",
 		},
+		{
+			name:    "SQLite",
+			dir:     "sqlite",
+			dialect: &dialect.SQLiteDialect{},
+		},
+		{
+			name:    "SQL Server",
+			dir:     "mssql",
+			dialect: &dialect.MSSQLDialect{},
+		},
+		{
+			name:    "PostgreSQL",
+			dir:     "postgres",
+			dialect: &dialect.PostgresqlDialect{},
+		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
+			d := c.dialect
+			if d == nil {
+				d = &dialect.GenericSQLDialect{}
+			}
 			fname := fmt.Sprintf("./testdata/%s/", c.dir)
 			files, err := ioutil.ReadDir(fname)
 			if err != nil {
@@ -138,7 +178,7 @@ func TestApply(t *testing.T) {
 						t.Fatalf("%+v", err)
 					}
 					defer fi.Close()
-					parser, err := xsqlparser.NewParser(fi, &dialect.GenericSQLDialect{})
+					parser, err := xsqlparser.NewParser(fi, d)
 					if err != nil {
 						t.Fatalf("%+v", err)
 					}
@@ -17,8 +17,9 @@ import (
 func TestParseQuery(t *testing.T) {
 
 	cases := []struct {
-		name string
-		dir  string
+		name    string
+		dir     string
+		dialect dialect.Dialect
 	}{
 		{
 			name: "SELECT",
@@ -48,10 +49,29 @@ func TestParseQuery(t *testing.T) {
 			name: "INSERT",
 			dir:  "insert",
 		},
+		{
+			name:    "SQLite",
+			dir:     "sqlite",
+			dialect: &dialect.SQLiteDialect{},
+		},
+		{
+			name:    "SQL Server",
+			dir:     "mssql",
+			dialect: &dialect.MSSQLDialect{},
+		},
+		{
+			name:    "PostgreSQL",
+			dir:     "postgres",
+			dialect: &dialect.PostgresqlDialect{},
+		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
+			d := c.dialect
+			if d == nil {
+				d = &dialect.GenericSQLDialect{}
+			}
 			fname := fmt.Sprintf("testdata/%s/", c.dir)
 			files, err := ioutil.ReadDir(fname)
 			if err != nil {
@@ -68,7 +88,7 @@ func TestParseQuery(t *testing.T) {
 						t.Fatalf("%+v", err)
 					}
 					defer fi.Close()
-					parser, err := xsqlparser.NewParser(fi, &dialect.GenericSQLDialect{})
+					parser, err := xsqlparser.NewParser(fi, d)
 					if err != nil {
 						t.Fatalf("%+v", err)
 					}
@@ -79,7 +99,7 @@ func TestParseQuery(t *testing.T) {
 					}
 					recovered := orig.ToSQLString()
 
-					parser, err = xsqlparser.NewParser(bytes.NewBufferString(recovered), &dialect.GenericSQLDialect{})
+					parser, err = xsqlparser.NewParser(bytes.NewBufferString(recovered), d)
 					if err != nil {
 						t.Log(recovered)
 						t.Fatalf("%+v", err)
@@ -92,7 +112,7 @@ func TestParseQuery(t *testing.T) {
 
 					recovered2 := stmt2.ToSQLString()
 
-					parser, err = xsqlparser.NewParser(bytes.NewBufferString(recovered2), &dialect.GenericSQLDialect{})
+					parser, err = xsqlparser.NewParser(bytes.NewBufferString(recovered2), d)
 					if err != nil {
 						t.Log(recovered)
 						t.Fatalf("%+v", err)
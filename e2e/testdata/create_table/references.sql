@@ -0,0 +1,6 @@
+CREATE TABLE orders (
+    id int primary key,
+    customer_id int references customers(id) on delete cascade on update set null,
+    product_id int references products(id) on delete no action,
+    note text
+)
@@ -0,0 +1 @@
+INSERT INTO audit_log DEFAULT VALUES;
@@ -0,0 +1,2 @@
+INSERT IGNORE INTO users
+SET id = 1, name = 'alice', created_at = DEFAULT;
@@ -0,0 +1,4 @@
+SELECT c.id, t.total, l.ordered_at
+FROM customers AS c
+CROSS APPLY dbo.customer_totals(c.id) AS t
+OUTER APPLY (SELECT TOP 1 o.ordered_at FROM orders AS o WHERE o.customer_id = c.id ORDER BY o.ordered_at DESC) AS l;
@@ -0,0 +1 @@
+DELETE FROM sessions OUTPUT deleted.* WHERE expires_at < @@DBTS;
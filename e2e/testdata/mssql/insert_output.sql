@@ -0,0 +1,3 @@
+INSERT INTO customers (name, note)
+OUTPUT inserted.id, inserted.name INTO @created (id, name)
+VALUES (N'山田', N'note');
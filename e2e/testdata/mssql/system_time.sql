@@ -0,0 +1,3 @@
+SELECT e.id, e.salary
+FROM dbo.employees FOR SYSTEM_TIME AS OF '2021-01-01T00:00:00' AS e
+WHERE e.department_id = 10;
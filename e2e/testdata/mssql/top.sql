@@ -0,0 +1,4 @@
+SELECT TOP (10) PERCENT WITH TIES [order id], total
+FROM [dbo].[orders] AS o WITH (NOLOCK)
+WHERE o.customer_id = @customer_id
+ORDER BY total DESC;
@@ -0,0 +1,3 @@
+UPDATE #balances SET amount = amount - @amount
+OUTPUT deleted.amount AS before_amount, inserted.amount AS after_amount
+WHERE account_id = @account_id;
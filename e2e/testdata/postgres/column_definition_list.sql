@@ -0,0 +1,2 @@
+SELECT r.a, r.b
+FROM json_to_recordset('[{"a": 1, "b": "x"}]') AS r (a int, b text);
@@ -0,0 +1,6 @@
+WITH moved_rows AS MATERIALIZED (
+    DELETE FROM products
+    WHERE date >= '2010-10-01' AND date < '2010-11-01'
+)
+INSERT INTO products_log
+SELECT * FROM moved_rows;
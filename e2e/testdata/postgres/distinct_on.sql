@@ -0,0 +1,3 @@
+SELECT DISTINCT ON (user_id) user_id, login_at, ip
+FROM logins
+ORDER BY user_id, login_at DESC;
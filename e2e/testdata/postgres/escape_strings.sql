@@ -0,0 +1,3 @@
+SELECT e'tab\tand \'quote\'', b'0101', x'1f'
+FROM t
+WHERE name = E'\\x';
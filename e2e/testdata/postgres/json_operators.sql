@@ -0,0 +1,5 @@
+SELECT data->>'id' AS id, data->'user'->>'name', data #> '{a,b}', data #>> '{a,b}'
+FROM events
+WHERE data @> '{"type": "click"}' AND '{"a": 1}' <@ data
+  AND data ? 'id' AND data ?| '{a,b}' AND data ?& '{a,b}'
+  AND tags && ARRAY['x', 'y'] AND ids @> ARRAY[1];
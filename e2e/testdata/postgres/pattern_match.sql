@@ -0,0 +1,5 @@
+SELECT name
+FROM users
+WHERE name ILIKE 'a%' OR name NOT ILIKE '%b'
+   OR code SIMILAR TO '(a|b)#_%' ESCAPE '#'
+   OR code NOT SIMILAR TO '%c%';
@@ -0,0 +1,10 @@
+WITH RECURSIVE search_tree (id, link, data) AS (
+    SELECT t.id, t.link, t.data
+    FROM tree t
+  UNION ALL
+    SELECT t.id, t.link, t.data
+    FROM tree t, search_tree st
+    WHERE t.id = st.link
+) SEARCH DEPTH FIRST BY id SET ordercol
+  CYCLE id SET is_cycle USING path
+SELECT * FROM search_tree ORDER BY ordercol;
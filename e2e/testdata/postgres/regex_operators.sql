@@ -0,0 +1,3 @@
+SELECT name || ' ' || surname AS full_name, flags # 4, 2 ^ 10
+FROM users
+WHERE name ~ '^a' OR name ~* '^b' OR name !~ 'c$' OR name !~* 'd$';
@@ -0,0 +1,6 @@
+SELECT id, payload
+FROM jobs
+WHERE state = 'queued'
+ORDER BY priority DESC, id
+LIMIT 10
+FOR UPDATE OF jobs SKIP LOCKED;
@@ -0,0 +1,3 @@
+SELECT e.user_id, count(*)
+FROM events AS e TABLESAMPLE BERNOULLI (10) REPEATABLE (42)
+GROUP BY e.user_id;
@@ -0,0 +1,4 @@
+INSERT INTO distributors (did, dname)
+VALUES (5, 'Gizmo Transglobal'), (6, 'Associated Computing, Inc')
+ON CONFLICT (did) DO UPDATE SET dname = EXCLUDED.dname || ' (formerly ' || distributors.dname || ')'
+RETURNING did, dname;
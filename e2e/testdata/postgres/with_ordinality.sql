@@ -0,0 +1,4 @@
+SELECT p.id, tag.value, tag.n
+FROM posts p
+CROSS JOIN LATERAL unnest(p.tags) WITH ORDINALITY AS tag (value, n)
+WHERE tag.n <= 3;
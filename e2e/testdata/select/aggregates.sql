@@ -0,0 +1,8 @@
+SELECT dept,
+  COUNT(DISTINCT user_id) AS users,
+  SUM(amount) FILTER (WHERE amount > 0) AS income,
+  percentile_cont(0.5) WITHIN GROUP (ORDER BY amount) AS median,
+  string_agg(name, ',' ORDER BY name DESC),
+  GROUP_CONCAT(DISTINCT name ORDER BY name SEPARATOR ';')
+FROM payments
+GROUP BY dept;
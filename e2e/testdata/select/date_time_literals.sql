@@ -0,0 +1,7 @@
+SELECT id, created_at + INTERVAL '1 day' AS next_day, DATE_ADD(created_at, INTERVAL 3 HOUR)
+FROM orders
+WHERE created_at >= TIMESTAMP '2020-01-01 00:00:00'
+  AND created_at < TIMESTAMP WITH TIME ZONE '2021-01-01 00:00:00+09'
+  AND shipped_on = DATE '2020-06-01'
+  AND cutoff > TIME '12:30:00'
+  AND age(created_at) < INTERVAL '1-6' YEAR TO MONTH;
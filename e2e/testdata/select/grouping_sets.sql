@@ -0,0 +1,4 @@
+SELECT region, product, SUM(amount) AS total, GROUPING(region, product) AS level
+FROM sales
+GROUP BY GROUPING SETS ((region, product), (region), ())
+HAVING SUM(amount) > 0;
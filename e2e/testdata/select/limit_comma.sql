@@ -0,0 +1,4 @@
+SELECT id, name
+FROM users
+ORDER BY name NULLS FIRST
+LIMIT 20, 10;
@@ -0,0 +1,7 @@
+SELECT dept,
+  SUM(salary) OVER w AS total,
+  rank() OVER (w ORDER BY salary DESC) AS salary_rank,
+  avg(salary) OVER (w ORDER BY hired_at GROUPS BETWEEN 1 PRECEDING AND 1 FOLLOWING EXCLUDE CURRENT ROW),
+  max(salary) OVER (ORDER BY hired_at RANGE UNBOUNDED PRECEDING EXCLUDE NO OTHERS)
+FROM employees
+WINDOW w AS (PARTITION BY dept), w2 AS (w ROWS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING EXCLUDE TIES);
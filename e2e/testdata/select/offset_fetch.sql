@@ -0,0 +1,5 @@
+SELECT id, name
+FROM users
+ORDER BY created_at DESC NULLS LAST, id
+OFFSET 20 ROWS
+FETCH NEXT 10 ROWS ONLY;
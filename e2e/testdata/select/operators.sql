@@ -0,0 +1,3 @@
+SELECT a || b, x & 4, x | 1, x ^ 2, ~x, x << 2, x >> 1, a <=> b, data->>'$.id'
+FROM t
+WHERE (flags & 4) = 4 AND x << 2 + 1 > 8;
@@ -0,0 +1,7 @@
+SELECT id, active IS NOT TRUE AS inactive
+FROM users
+WHERE a IS DISTINCT FROM b
+  AND c IS NOT DISTINCT FROM d
+  AND verified IS UNKNOWN
+  AND name LIKE 'a!%%' ESCAPE '!'
+  AND email REGEXP '@example\.com$';
@@ -0,0 +1,3 @@
+SELECT region, product, SUM(amount)
+FROM sales
+GROUP BY DISTINCT ROLLUP (region, product), CUBE ((region, product), channel);
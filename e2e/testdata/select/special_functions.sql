@@ -0,0 +1,6 @@
+SELECT EXTRACT(YEAR FROM created_at) AS y,
+  SUBSTRING(name FROM 2 FOR 3), SUBSTRING(name, 1, 2),
+  TRIM(BOTH ' ' FROM name), TRIM(name),
+  OVERLAY(code PLACING 'xx' FROM 2 FOR 2)
+FROM users
+WHERE POSITION('@' IN email) > 0;
@@ -0,0 +1,5 @@
+SELECT 'it''s', N'it''s', E'line\nbreak\'s', B'0101', X'1F', $$it's $$, $fn$
+  multi line $$ body
+$fn$
+FROM t
+WHERE flags = B'1' OR name = E'\\x';
@@ -0,0 +1,3 @@
+SELECT v.id, v.name
+FROM (VALUES (1, 'one'), (2, 'two'), (3, 'three')) AS v (id, name)
+WHERE v.id IN (SELECT id FROM t);
@@ -0,0 +1,3 @@
+SELECT region, SUM(amount)
+FROM sales
+GROUP BY region WITH ROLLUP;
@@ -0,0 +1 @@
+ATTACH DATABASE 'archive.db' AS archive;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS [order items] (
+    `id`       INTEGER PRIMARY KEY AUTOINCREMENT,
+    "order_id" INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    name       TEXT NOT NULL
+) WITHOUT ROWID, STRICT;
@@ -0,0 +1 @@
+INSERT OR REPLACE INTO users (id, name) VALUES (1, 'a'), (2, 'b');
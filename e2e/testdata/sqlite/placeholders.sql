@@ -0,0 +1,3 @@
+SELECT id, name FROM users
+WHERE id = ?1 OR name = @name OR email = $email OR nickname = :nickname
+LIMIT ?2;
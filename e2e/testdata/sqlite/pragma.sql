@@ -0,0 +1 @@
+PRAGMA main.journal_mode = WAL;
@@ -0,0 +1 @@
+PRAGMA table_info([order items]);
@@ -0,0 +1 @@
+REPLACE INTO users (id, name) SELECT id, name FROM archive.users;
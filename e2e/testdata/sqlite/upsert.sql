@@ -0,0 +1,3 @@
+INSERT INTO vocabulary (word) VALUES ('jovial')
+ON CONFLICT (word) DO UPDATE SET count = count + 1
+RETURNING count;
@@ -0,0 +1,171 @@
+package xsqlparser
+
+import (
+	"fmt"
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/moomou/xsqlparser/sqlast"
+	"github.com/moomou/xsqlparser/sqltoken"
+)
+
+// ParseError is the error returned by the parser when the input is not valid sql.
+// It can be retrieved from any error returned by the parser with errors.As.
+type ParseError struct {
+	Pos              sqltoken.Pos    // position of Found, or end of input if Found is nil
+	Found            *sqltoken.Token // nil at end of input
+	ExpectedKinds    []sqltoken.Kind
+	ExpectedKeywords []string
+	StmtIndex        int    // index of the failed statement in ParseSQL
+	Msg              string // set when the error is not about an expected token
+
+	err error
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d:%d: ", e.Pos.Line, e.Pos.Col)
+
+	if e.Msg != "" {
+		b.WriteString(e.Msg)
+		return b.String()
+	}
+
+	var expected []string
+	for _, k := range e.ExpectedKinds {
+		expected = append(expected, k.String())
+	}
+	expected = append(expected, e.ExpectedKeywords...)
+
+	fmt.Fprintf(&b, "expected %s but found %s", strings.Join(expected, " or "), tokenString(e.Found))
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.err
+}
+
+// Diagnostic reports a statement which was skipped in error recovery mode.
+type Diagnostic struct {
+	From, To sqltoken.Pos // range of the skipped statement
+	Err      *ParseError
+}
+
+func newDiagnostic(bad *sqlast.BadStmt, err error) *Diagnostic {
+	d := &Diagnostic{From: bad.From, To: bad.To}
+	errors.As(err, &d.Err)
+	return d
+}
+
+func (d *Diagnostic) Error() string {
+	return d.Err.Error()
+}
+
+// Diagnostics is the error returned with partial results in error recovery mode.
+type Diagnostics []*Diagnostic
+
+func (d Diagnostics) Error() string {
+	switch len(d) {
+	case 0:
+		return "no errors"
+	case 1:
+		return d[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", d[0].Error(), len(d)-1)
+}
+
+func tokenString(tok *sqltoken.Token) string {
+	if tok == nil {
+		return "end of input"
+	}
+	if w, ok := tok.Value.(*sqltoken.SQLWord); ok {
+		return fmt.Sprintf("%q", w.String())
+	}
+	return fmt.Sprintf("%q", fmt.Sprint(tok.Value))
+}
+
+// expected returns a ParseError for found when one of kinds is required.
+func (p *Parser) expected(found *sqltoken.Token, kinds ...sqltoken.Kind) error {
+	return &ParseError{
+		Pos:           p.errorPos(found),
+		Found:         found,
+		ExpectedKinds: kinds,
+	}
+}
+
+// expectedKeywords returns a ParseError for found when one of keywords is required.
+func (p *Parser) expectedKeywords(found *sqltoken.Token, keywords ...string) error {
+	return &ParseError{
+		Pos:              p.errorPos(found),
+		Found:            found,
+		ExpectedKeywords: keywords,
+	}
+}
+
+// errorf returns a ParseError at found with a formatted message.
+func (p *Parser) errorf(found *sqltoken.Token, format string, args ...interface{}) error {
+	return &ParseError{
+		Pos:   p.errorPos(found),
+		Found: found,
+		Msg:   fmt.Sprintf(format, args...),
+	}
+}
+
+// unsupported returns a ParseError at found for syntax which the dialect does not accept.
+func (p *Parser) unsupported(found *sqltoken.Token, syntax string) error {
+	return p.errorf(found, "%s is not supported by %T", syntax, p.dialect)
+}
+
+// toParseError makes sure that err carries a ParseError.
+// Errors which are not from the parser itself (e.g. EOF) are wrapped at the current position.
+func (p *Parser) toParseError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		return err
+	}
+
+	var found *sqltoken.Token
+	if err != EOF {
+		found, _ = p.peekToken()
+	}
+
+	perr = &ParseError{
+		Pos:   p.errorPos(found),
+		Found: found,
+		err:   err,
+	}
+	if err == EOF {
+		perr.Msg = "unexpected end of input"
+	} else {
+		perr.Msg = err.Error()
+	}
+
+	return perr
+}
+
+// withStmtIndex records the index of the failed statement on the ParseError of err.
+func (p *Parser) withStmtIndex(err error, i int) error {
+	err = p.toParseError(err)
+
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		perr.StmtIndex = i
+	}
+
+	return err
+}
+
+func (p *Parser) errorPos(found *sqltoken.Token) sqltoken.Pos {
+	if found != nil {
+		return found.From
+	}
+	if len(p.tokens) == 0 {
+		return sqltoken.NewPos(1, 1)
+	}
+	return p.tokens[len(p.tokens)-1].To
+}
@@ -0,0 +1,210 @@
+package xsqlparser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	errors "golang.org/x/xerrors"
+
+	"github.com/moomou/xsqlparser/dialect"
+	"github.com/moomou/xsqlparser/sqltoken"
+)
+
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  *ParseError
+	}{
+		{
+			name: "missing keyword",
+			in:   "CREATE VIEW v SELECT 1",
+			out: &ParseError{
+				Pos:              sqltoken.NewPos(1, 15),
+				ExpectedKeywords: []string{"AS"},
+			},
+		},
+		{
+			name: "missing token",
+			in:   "SELECT a FROM t WHERE a IN (1, 2",
+			out: &ParseError{
+				Pos:           sqltoken.NewPos(1, 33),
+				ExpectedKinds: []sqltoken.Kind{sqltoken.RParen},
+			},
+		},
+		{
+			name: "unexpected end of input",
+			in:   "SELECT a FROM",
+			out: &ParseError{
+				Pos:           sqltoken.NewPos(1, 14),
+				ExpectedKinds: []sqltoken.Kind{sqltoken.SQLKeyword},
+			},
+		},
+		{
+			name: "second statement",
+			in:   "SELECT a FROM t;\nDELETE t",
+			out: &ParseError{
+				Pos:              sqltoken.NewPos(2, 8),
+				ExpectedKeywords: []string{"FROM"},
+				StmtIndex:        1,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = parser.ParseSQL()
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("must be ParseError but %+v", err)
+			}
+
+			if diff := cmp.Diff(c.out, perr, cmp.FilterPath(func(p cmp.Path) bool {
+				return p.Last().String() == ".Found" || p.Last().String() == ".err"
+			}, cmp.Ignore())); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+		})
+	}
+}
+
+func TestParser_TruncatedInput(t *testing.T) {
+	files, err := filepath.Glob("e2e/testdata/*/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		t.Run(f, func(t *testing.T) {
+			src, err := ioutil.ReadFile(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tokens, err := sqltoken.NewTokenizer(bytes.NewBuffer(src), &dialect.GenericSQLDialect{}).Tokenize()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// every prefix of the input must be rejected with an error, not a panic
+			for i := range tokens {
+				parser := NewParserWithOptions()
+				parser.SetTokens(tokens[:i])
+
+				_, err := parser.ParseSQL()
+				var perr *ParseError
+				if err != nil && !errors.As(err, &perr) {
+					t.Errorf("must be ParseError but %+v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_RecoverErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		in    string
+		out   string
+		diags []*Diagnostic
+	}{
+		{
+			name: "no errors",
+			in:   "SELECT a FROM t;\nDELETE FROM t",
+			out:  "SELECT a FROM t\nDELETE FROM t",
+		},
+		{
+			name: "skip a statement",
+			in:   "SELECT a FROM t;\nVACUUM t FULL;\nDELETE FROM t",
+			out:  "SELECT a FROM t\n\nDELETE FROM t",
+			diags: []*Diagnostic{
+				{
+					From: sqltoken.NewPos(2, 1),
+					To:   sqltoken.NewPos(2, 14),
+					Err: &ParseError{
+						Pos:       sqltoken.NewPos(2, 1),
+						Msg:       "unexpected (or unsupported) keyword VACUUM",
+						StmtIndex: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "skip multiple statements",
+			in:   "SELECT a FROM;\nSELECT b FROM t;\nCREATE VIEW v SELECT 1",
+			out:  "\nSELECT b FROM t\n",
+			diags: []*Diagnostic{
+				{
+					From: sqltoken.NewPos(1, 1),
+					To:   sqltoken.NewPos(1, 14),
+					Err: &ParseError{
+						Pos:           sqltoken.NewPos(1, 14),
+						ExpectedKinds: []sqltoken.Kind{sqltoken.SQLKeyword},
+					},
+				},
+				{
+					From: sqltoken.NewPos(3, 1),
+					To:   sqltoken.NewPos(3, 23),
+					Err: &ParseError{
+						Pos:              sqltoken.NewPos(3, 15),
+						ExpectedKeywords: []string{"AS"},
+						StmtIndex:        2,
+					},
+				},
+			},
+		},
+		{
+			name: "missing delimiter",
+			in:   "SELECT a FROM t WHERE a = 1 1 2;\nDELETE FROM t",
+			out:  "SELECT a FROM t WHERE a = 1\n\nDELETE FROM t",
+			diags: []*Diagnostic{
+				{
+					From: sqltoken.NewPos(1, 29),
+					To:   sqltoken.NewPos(1, 32),
+					Err: &ParseError{
+						Pos:           sqltoken.NewPos(1, 29),
+						ExpectedKinds: []sqltoken.Kind{sqltoken.Semicolon},
+						StmtIndex:     1,
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{}, RecoverErrors())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parser.ParseFile()
+			if c.diags == nil && err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			var diags Diagnostics
+			if c.diags != nil && !errors.As(err, &diags) {
+				t.Fatalf("must be Diagnostics but %+v", err)
+			}
+
+			if diff := cmp.Diff([]*Diagnostic(diags), c.diags, cmp.FilterPath(func(p cmp.Path) bool {
+				return p.Last().String() == ".Found" || p.Last().String() == ".err"
+			}, cmp.Ignore())); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+
+			if act := f.ToSQLString(); act != c.out {
+				t.Errorf("must be \n%s\n but \n%s", c.out, act)
+			}
+		})
+	}
+}
@@ -165,8 +165,8 @@ func testVirtualTable() {
 
 	pp.Println(virtualTableStmt)
 	log.Printf("NotExists: %v", virtualTableStmt.NotExists)
-	log.Printf("Table Name: %s", virtualTableStmt.Name)
-	log.Printf("Using: %s", virtualTableStmt.Using)
+	log.Printf("Table Name: %s", virtualTableStmt.Name.ToSQLString())
+	log.Printf("Using: %s", virtualTableStmt.Using.ToSQLString())
 	log.Printf("Arguments: %v", virtualTableStmt.Arguments)
 
 	log.Println("Round-trip SQL:")
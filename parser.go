@@ -1,12 +1,13 @@
 package xsqlparser
 
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"log"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	errors "golang.org/x/xerrors"
 
@@ -16,10 +17,17 @@ import (
 )
 
 type Parser struct {
-	tokens       []*sqltoken.Token
-	index        uint
-	comments     map[sqltoken.Pos]*sqlast.CommentGroup
-	parseComment bool
+	src           []byte
+	tokens        []*sqltoken.Token
+	index         uint
+	dialect       dialect.Dialect
+	comments      map[sqltoken.Pos]*sqlast.CommentGroup
+	parseComment  bool
+	depth         int
+	maxDepth      int
+	maxStatements int
+	recovery      bool
+	rawStmt       bool
 }
 
 type ParserOption func(*Parser)
@@ -31,24 +39,68 @@ func ParseComment() ParserOption {
 	}
 }
 
-func NewParser(src io.Reader, dialect dialect.Dialect, opts ...ParserOption) (*Parser, error) {
-	tokenizer := sqltoken.NewTokenizer(src, dialect)
-	set, err := tokenizer.Tokenize()
-	if err != nil {
-		return nil, errors.Errorf("tokenize err failed: %w", err)
+// Dialect sets the dialect used to tokenize and parse the source.
+// It overrides the dialect passed to NewParser.
+func Dialect(d dialect.Dialect) ParserOption {
+	return func(p *Parser) {
+		p.dialect = d
+	}
+}
+
+// MaxDepth limits the nesting depth of expressions and subqueries.
+// Zero means no limit.
+func MaxDepth(n int) ParserOption {
+	return func(p *Parser) {
+		p.maxDepth = n
+	}
+}
+
+// RecoverErrors makes ParseSQL and ParseFile skip statements which cannot be parsed
+// instead of failing. Each skipped statement is replaced with a sqlast.BadStmt
+// and reported in the returned Diagnostics.
+func RecoverErrors() ParserOption {
+	return func(p *Parser) {
+		p.recovery = true
+	}
+}
+
+// RawStatements makes the parser keep unsupported statements as sqlast.RawStmt
+// instead of failing, e.g. GRANT, VACUUM or CREATE FUNCTION.
+func RawStatements() ParserOption {
+	return func(p *Parser) {
+		p.rawStmt = true
+	}
+}
+
+// MaxStatements limits the number of statements ParseSQL and ParseFile accept.
+// Zero means no limit.
+func MaxStatements(n int) ParserOption {
+	return func(p *Parser) {
+		p.maxStatements = n
 	}
+}
 
-	parser := &Parser{tokens: set, index: 0}
+func NewParser(src io.Reader, dialect dialect.Dialect, opts ...ParserOption) (*Parser, error) {
+	parser := &Parser{index: 0, dialect: dialect}
 
 	for _, o := range opts {
 		o(parser)
 	}
 
+	var buf bytes.Buffer
+	tokenizer := sqltoken.NewTokenizer(io.TeeReader(src, &buf), parser.dialect)
+	set, err := tokenizer.Tokenize()
+	if err != nil {
+		return nil, errors.Errorf("tokenize err failed: %w", err)
+	}
+	parser.tokens = set
+	parser.src = buf.Bytes()
+
 	return parser, nil
 }
 
 func NewParserWithOptions(opts ...ParserOption) *Parser {
-	parser := &Parser{index: 0}
+	parser := &Parser{index: 0, dialect: &dialect.GenericSQLDialect{}}
 	for _, o := range opts {
 		o(parser)
 	}
@@ -63,7 +115,7 @@ func (p *Parser) SetTokens(tokens []*sqltoken.Token) {
 
 func (p *Parser) ParseFile() (*sqlast.File, error) {
 	stmts, err := p.ParseSQL()
-	if err != nil {
+	if _, ok := err.(Diagnostics); err != nil && !ok {
 		return nil, err
 	}
 
@@ -80,18 +132,32 @@ func (p *Parser) ParseFile() (*sqlast.File, error) {
 	return &sqlast.File{
 		Stmts:    stmts,
 		Comments: comments,
-	}, nil
+	}, err
 }
 
+// ParseSQL parses semicolon separated statements.
+// The returned error carries a *ParseError whose StmtIndex is the index of the failed statement.
+// With RecoverErrors, the statements are returned together with Diagnostics when some of them failed.
 func (p *Parser) ParseSQL() ([]sqlast.Stmt, error) {
 	var stmts []sqlast.Stmt
+	var diags Diagnostics
 	var expectingDelimiter bool
 
 	for {
 		ok, _ := p.consumeToken(sqltoken.Semicolon)
 		if !ok && expectingDelimiter {
-			tok, _ := p.peekToken()
-			return nil, errors.Errorf("expect semicolon but %+v", tok)
+			// the last statement does not need a delimiter
+			if tok, err := p.peekToken(); err != EOF {
+				err := p.withStmtIndex(p.expected(tok, sqltoken.Semicolon), len(stmts))
+				if !p.recovery {
+					return nil, err
+				}
+				bad := p.skipStatement(p.index)
+				diags = append(diags, newDiagnostic(bad, err))
+				stmts = append(stmts, bad)
+				expectingDelimiter = false
+				continue
+			}
 		}
 
 		if p.parseComment {
@@ -114,32 +180,84 @@ func (p *Parser) ParseSQL() ([]sqlast.Stmt, error) {
 			}
 		}
 
-		stmt, err := p.ParseStatement()
+		if p.maxStatements > 0 && len(stmts) >= p.maxStatements {
+			tok, _ := p.peekToken()
+			return nil, p.withStmtIndex(p.errorf(tok, "too many statements: limit is %d", p.maxStatements), len(stmts))
+		}
+
+		start := p.index
+		stmt, err := p.parseStatement()
 		if err != nil {
-			return nil, errors.Errorf("parseStatement failed: %w", err)
+			err := p.withStmtIndex(err, len(stmts))
+			if !p.recovery {
+				return nil, errors.Errorf("parseStatement failed: %w", err)
+			}
+			bad := p.skipStatement(start)
+			diags = append(diags, newDiagnostic(bad, err))
+			stmts = append(stmts, bad)
+			expectingDelimiter = false
+			continue
 		}
 		stmts = append(stmts, stmt)
 		expectingDelimiter = true
 
 	}
 
+	if len(diags) != 0 {
+		return stmts, diags
+	}
+
 	return stmts, nil
 }
 
+// skipStatement moves to the token after the next semicolon from start
+// and returns the skipped range as BadStmt.
+func (p *Parser) skipStatement(start uint) *sqlast.BadStmt {
+	p.index = start
+	p.depth = 0
+
+	bad := &sqlast.BadStmt{}
+	if tok, err := p.peekToken(); err == nil {
+		bad.From, bad.To = tok.From, tok.To
+	}
+
+	for {
+		tok, err := p.nextToken()
+		if err != nil || tok.Kind == sqltoken.Semicolon {
+			break
+		}
+		bad.To = tok.To
+	}
+
+	return bad
+}
+
+// ParseStatement parses a single statement. The returned error carries a *ParseError.
 func (p *Parser) ParseStatement() (sqlast.Stmt, error) {
+	stmt, err := p.parseStatement()
+	if err != nil {
+		return nil, p.toParseError(err)
+	}
+	return stmt, nil
+}
+
+func (p *Parser) parseStatement() (sqlast.Stmt, error) {
 	tok, err := p.nextToken()
 	if err != nil {
 		return nil, err
 	}
 	word, ok := tok.Value.(*sqltoken.SQLWord)
 	if !ok {
-		return nil, errors.Errorf("a keyword at the beginning of statement %s", tok.Value)
+		return nil, p.errorf(tok, "expected a keyword at the beginning of statement but found %s", tokenString(tok))
 	}
 
 	switch word.Keyword {
-	case "SELECT", "WITH":
+	case "SELECT", "VALUES":
 		p.prevToken()
 		return p.parseQuery()
+	case "WITH":
+		p.prevToken()
+		return p.parseWithStatement()
 	case "CREATE":
 		p.prevToken()
 		return p.parseCreate()
@@ -159,24 +277,53 @@ func (p *Parser) ParseStatement() (sqlast.Stmt, error) {
 		p.prevToken()
 		return p.parseDrop()
 	case "EXPLAIN":
-		stmt, err := p.ParseStatement()
+		stmt, err := p.parseStatement()
 		if err != nil {
 			return nil, err
 		}
 		return &sqlast.ExplainStmt{Stmt: stmt}, nil
-	default:
-		return nil, errors.Errorf("unexpected (or unsupported) keyword %s", word.Keyword)
+	case "REPLACE":
+		if p.dialect.Supports(dialect.ReplaceInto) {
+			p.prevToken()
+			return p.parseInsert()
+		}
+	case "PRAGMA":
+		if p.dialect.Supports(dialect.Pragma) {
+			return p.parsePragma(tok)
+		}
+	case "ATTACH":
+		if p.dialect.Supports(dialect.AttachDatabase) {
+			return p.parseAttach(tok)
+		}
+	case "DETACH":
+		if p.dialect.Supports(dialect.AttachDatabase) {
+			return p.parseDetach(tok)
+		}
+	}
+
+	if p.rawStmt {
+		return p.parseRawStmt(tok)
 	}
+	return nil, p.errorf(tok, "unexpected (or unsupported) keyword %s", strings.ToUpper(word.Value))
 }
 
+// ParseDataType parses a data type such as `varchar(255)`. The returned error carries a *ParseError.
 func (p *Parser) ParseDataType() (sqlast.Type, error) {
+	tp, err := p.parseDataType()
+	if err != nil {
+		return nil, p.toParseError(err)
+	}
+	return tp, nil
+}
+
+func (p *Parser) parseDataType() (sqlast.Type, error) {
 	tok, err := p.nextToken()
 	if err != nil {
 		return nil, errors.Errorf("nextToken failed: %w", err)
 	}
 	word, ok := tok.Value.(*sqltoken.SQLWord)
 	if !ok {
-		return nil, errors.Errorf("must be datatype name but %v", tok)
+		return nil, p.errorf(tok, "expected a data type name but found %s", tokenString(tok))
 	}
 
 	switch word.Keyword {
@@ -196,7 +343,10 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 		unsigned, pos := p.parseMyUnsigned()
 		return &sqlast.Real{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: pos}, nil
 	case "DOUBLE":
-		p := p.expectKeyword("PRECISION")
+		p, err := p.expectKeyword("PRECISION")
+		if err != nil {
+			return nil, err
+		}
 		return &sqlast.Double{From: tok.From, To: p.To}, nil
 	case "SMALLINT":
 		unsigned, pos := p.parseMyUnsigned()
@@ -205,8 +355,8 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 		unsigned, pos := p.parseMyUnsigned()
 		return &sqlast.Int{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: pos}, nil
 	case "BIGINT":
-		unsigned, u, _ := p.parseKeyword("UNSIGNED")
-		return &sqlast.BigInt{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: u.To}, nil
+		unsigned, pos := p.parseMyUnsigned()
+		return &sqlast.BigInt{From: tok.From, To: tok.To, IsUnsigned: unsigned, Unsigned: pos}, nil
 	case "VARCHAR":
 		p, r, err := p.parseOptionalPrecision()
 		if err != nil {
@@ -236,8 +386,12 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 		wok, _, _ := p.parseKeyword("WITH")
 		ook, _, _ := p.parseKeyword("WITHOUT")
 		if wok || ook {
-			p.expectKeyword("TIME")
-			p.expectKeyword("ZONE")
+			if _, err := p.expectKeyword("TIME"); err != nil {
+				return nil, err
+			}
+			if _, err := p.expectKeyword("ZONE"); err != nil {
+				return nil, err
+			}
 		}
 		return &sqlast.Timestamp{
 			Timestamp:    tok.From,
@@ -247,15 +401,21 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 		wok, _, _ := p.parseKeyword("WITH")
 		ook, _, _ := p.parseKeyword("WITHOUT")
 		if wok || ook {
-			p.expectKeyword("TIME")
-			p.expectKeyword("ZONE")
+			if _, err := p.expectKeyword("TIME"); err != nil {
+				return nil, err
+			}
+			if _, err := p.expectKeyword("ZONE"); err != nil {
+				return nil, err
+			}
 		}
 		return &sqlast.Time{}, nil
 	case "REGCLASS":
 		return &sqlast.Regclass{}, nil
 	case "TEXT":
 		if ok, _ := p.consumeToken(sqltoken.LBracket); ok {
-			p.expectToken(sqltoken.RBracket)
+			if _, err := p.expectToken(sqltoken.RBracket); err != nil {
+				return nil, err
+			}
 			return &sqlast.Array{
 				Ty: &sqlast.Text{},
 			}, nil
@@ -269,9 +429,9 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 			return nil, errors.Errorf("parseOptionalPrecisionScale failed: %w", err)
 		}
 		p.prevToken()
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %s", r)
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
 		}
 
 		unsigned, pos := p.parseMyUnsigned()
@@ -296,21 +456,105 @@ func (p *Parser) ParseDataType() (sqlast.Type, error) {
 	}
 }
 
+// ParseExpr parses an expression. The returned error carries a *ParseError.
 func (p *Parser) ParseExpr() (sqlast.Node, error) {
-	return p.parseSubexpr(0)
+	expr, err := p.parseSubexpr(0)
+	if err != nil {
+		return nil, p.toParseError(err)
+	}
+	return expr, nil
 }
 
-func (p *Parser) parseQuery() (*sqlast.QueryStmt, error) {
-	hasCTE, _, _ := p.parseKeyword("WITH")
-	var ctes []*sqlast.CTE
-	if hasCTE {
-		cts, err := p.parseCTEList()
+// withClause is `WITH [RECURSIVE] ctes...` which is parsed before the statement it belongs to.
+type withClause struct {
+	with      sqltoken.Pos
+	recursive bool
+	ctes      []*sqlast.CTE
+}
+
+// parseWith parses `WITH [RECURSIVE] ctes...` and returns nil if there is no WITH.
+func (p *Parser) parseWith() (*withClause, error) {
+	ok, tok, _ := p.parseKeyword("WITH")
+	if !ok {
+		return nil, nil
+	}
+	recursive, _, _ := p.parseKeyword("RECURSIVE")
+	ctes, err := p.parseCTEList()
+	if err != nil {
+		return nil, errors.Errorf("parseCTEList failed: %w", err)
+	}
+
+	return &withClause{
+		with:      tok.From,
+		recursive: recursive,
+		ctes:      ctes,
+	}, nil
+}
+
+// parseWithStatement parses a statement which starts with WITH.
+// The CTEs are attached to the following SELECT, INSERT, UPDATE or DELETE.
+func (p *Parser) parseWithStatement() (sqlast.Stmt, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	with, err := p.parseWith()
+	if err != nil {
+		return nil, errors.Errorf("parseWith failed: %w", err)
+	}
+
+	tok, _ := p.peekToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return p.parseQueryAfterWith(with)
+	}
+
+	switch tok.Value.(*sqltoken.SQLWord).Keyword {
+	case "INSERT":
+		stmt, err := p.parseInsert()
+		if err != nil {
+			return nil, errors.Errorf("parseInsert failed: %w", err)
+		}
+		i := stmt.(*sqlast.InsertStmt)
+		i.With, i.Recursive, i.CTEs = with.with, with.recursive, with.ctes
+		return i, nil
+	case "UPDATE":
+		stmt, err := p.parseUpdate()
+		if err != nil {
+			return nil, errors.Errorf("parseUpdate failed: %w", err)
+		}
+		u := stmt.(*sqlast.UpdateStmt)
+		u.With, u.Recursive, u.CTEs = with.with, with.recursive, with.ctes
+		return u, nil
+	case "DELETE":
+		stmt, err := p.parseDelete()
 		if err != nil {
-			return nil, errors.Errorf("parseCTEList failed: %w", err)
+			return nil, errors.Errorf("parseDelete failed: %w", err)
 		}
-		ctes = cts
+		d := stmt.(*sqlast.DeleteStmt)
+		d.With, d.Recursive, d.CTEs = with.with, with.recursive, with.ctes
+		return d, nil
+	}
+
+	return p.parseQueryAfterWith(with)
+}
+
+func (p *Parser) parseQuery() (*sqlast.QueryStmt, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	with, err := p.parseWith()
+	if err != nil {
+		return nil, errors.Errorf("parseWith failed: %w", err)
 	}
 
+	return p.parseQueryAfterWith(with)
+}
+
+// parseQueryAfterWith parses the rest of the query after the optional WITH clause.
+func (p *Parser) parseQueryAfterWith(with *withClause) (*sqlast.QueryStmt, error) {
 	body, err := p.parseQueryBody(0)
 	if err != nil {
 		return nil, errors.Errorf("parseQueryBody failed: %w", err)
@@ -325,21 +569,142 @@ func (p *Parser) parseQuery() (*sqlast.QueryStmt, error) {
 		orderBy = o
 	}
 
-	var limit *sqlast.LimitExpr
-	if ok, _, _ := p.parseKeyword("LIMIT"); ok {
-		l, err := p.parseLimit()
+	// locking clauses may come either before or after LIMIT like PostgreSQL accepts
+	locks, err := p.parseLockingClauses()
+	if err != nil {
+		return nil, errors.Errorf("parseLockingClauses failed: %w", err)
+	}
+
+	limit, err := p.parseLimitClause()
+	if err != nil {
+		return nil, errors.Errorf("parseLimitClause failed: %w", err)
+	}
+	locksBeforeLimit := len(locks) != 0 && limit != nil
+
+	if len(locks) == 0 {
+		locks, err = p.parseLockingClauses()
+		if err != nil {
+			return nil, errors.Errorf("parseLockingClauses failed: %w", err)
+		}
+	}
+
+	q := &sqlast.QueryStmt{
+		Body:             body,
+		Limit:            limit,
+		OrderBy:          orderBy,
+		Locks:            locks,
+		LocksBeforeLimit: locksBeforeLimit,
+	}
+	if with != nil {
+		q.With, q.Recursive, q.CTEs = with.with, with.recursive, with.ctes
+	}
+
+	return q, nil
+}
+
+// parseLimitClause parses LIMIT, OFFSET or FETCH. It returns nil if there is none of them.
+func (p *Parser) parseLimitClause() (*sqlast.LimitExpr, error) {
+	if ok, tok, _ := p.parseKeyword("LIMIT"); ok {
+		l, err := p.parseLimit(tok)
 		if err != nil {
 			return nil, errors.Errorf("invalid limit expression: %w", err)
 		}
-		limit = l
+		return l, nil
+	} else if ok, tok, _ := p.parseKeyword("OFFSET"); ok {
+		l, err := p.parseOffsetFetch(tok)
+		if err != nil {
+			return nil, errors.Errorf("parseOffsetFetch failed: %w", err)
+		}
+		return l, nil
+	} else if ok, tok, _ := p.parseKeyword("FETCH"); ok {
+		l, err := p.parseOffsetFetch(tok)
+		if err != nil {
+			return nil, errors.Errorf("parseOffsetFetch failed: %w", err)
+		}
+		return l, nil
 	}
+	return nil, nil
+}
 
-	return &sqlast.QueryStmt{
-		CTEs:    ctes,
-		Body:    body,
-		Limit:   limit,
-		OrderBy: orderBy,
-	}, nil
+func (p *Parser) parseLockingClauses() ([]*sqlast.LockingClause, error) {
+	var locks []*sqlast.LockingClause
+	for {
+		lock, err := p.parseLockingClause()
+		if err != nil {
+			return nil, errors.Errorf("parseLockingClause failed: %w", err)
+		}
+		if lock == nil {
+			return locks, nil
+		}
+		locks = append(locks, lock)
+	}
+}
+
+// parseLockingClause parses FOR UPDATE, FOR NO KEY UPDATE, FOR SHARE, FOR KEY SHARE
+// with the optional OF, NOWAIT and SKIP LOCKED, or LOCK IN SHARE MODE.
+// It returns nil if there is no locking clause.
+func (p *Parser) parseLockingClause() (*sqlast.LockingClause, error) {
+	tok, _ := p.peekToken()
+	if tok == nil {
+		return nil, nil
+	}
+
+	if ok, toks, _ := p.parseKeywords("LOCK", "IN", "SHARE", "MODE"); ok {
+		if !p.dialect.Supports(dialect.LockInShareMode) {
+			return nil, p.unsupported(tok, "LOCK IN SHARE MODE")
+		}
+		return &sqlast.LockingClause{
+			From:     tok.From,
+			Strength: sqlast.LockInShareMode,
+			To:       toks[3].To,
+		}, nil
+	}
+
+	lock := &sqlast.LockingClause{From: tok.From}
+	if ok, toks, _ := p.parseKeywords("FOR", "UPDATE"); ok {
+		lock.Strength = sqlast.ForUpdate
+		lock.To = toks[1].To
+	} else if ok, toks, _ := p.parseKeywords("FOR", "NO", "KEY", "UPDATE"); ok {
+		lock.Strength = sqlast.ForNoKeyUpdate
+		lock.To = toks[3].To
+	} else if ok, toks, _ := p.parseKeywords("FOR", "SHARE"); ok {
+		lock.Strength = sqlast.ForShare
+		lock.To = toks[1].To
+	} else if ok, toks, _ := p.parseKeywords("FOR", "KEY", "SHARE"); ok {
+		lock.Strength = sqlast.ForKeyShare
+		lock.To = toks[2].To
+	} else {
+		return nil, nil
+	}
+
+	if !p.dialect.Supports(dialect.LockingClause) {
+		return nil, p.unsupported(tok, "locking clause")
+	}
+
+	if ok, _, _ := p.parseKeyword("OF"); ok {
+		for {
+			table, err := p.parseObjectName()
+			if err != nil {
+				return nil, errors.Errorf("parseObjectName failed: %w", err)
+			}
+			lock.Of = append(lock.Of, table)
+			lock.To = table.End()
+
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+	}
+
+	if ok, t, _ := p.parseKeyword("NOWAIT"); ok {
+		lock.Wait = sqlast.LockNoWait
+		lock.To = t.To
+	} else if ok, toks, _ := p.parseKeywords("SKIP", "LOCKED"); ok {
+		lock.Wait = sqlast.LockSkipLocked
+		lock.To = toks[1].To
+	}
+
+	return lock, nil
 }
 
 func (p *Parser) parseQueryBody(precedence uint8) (sqlast.SQLSetExpr, error) {
@@ -351,17 +716,35 @@ func (p *Parser) parseQueryBody(precedence uint8) (sqlast.SQLSetExpr, error) {
 		}
 		s.Select = tok.From
 		expr = s
+	} else if ok, tok, _ := p.parseKeyword("VALUES"); ok {
+		v, err := p.parseValues(tok)
+		if err != nil {
+			return nil, errors.Errorf("parseValues failed: %w", err)
+		}
+		expr = v
 	} else if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		l := p.tokens[p.index-1]
 		subquery, err := p.parseQuery()
 		if err != nil {
 			return nil, errors.Errorf("parseQuery failed: %w", err)
 		}
-		p.expectToken(sqltoken.RParen)
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
 		expr = &sqlast.QueryExpr{
-			Query: subquery,
+			LParen: l.From,
+			RParen: r.To,
+			Query:  subquery,
 		}
 	} else {
-		log.Panicln("expect SELECT or subquery in the query body")
+		tok, _ := p.peekToken()
+		return nil, &ParseError{
+			Pos:              p.errorPos(tok),
+			Found:            tok,
+			ExpectedKinds:    []sqltoken.Kind{sqltoken.LParen},
+			ExpectedKeywords: []string{"SELECT", "VALUES"},
+		}
 	}
 BODY_LOOP:
 	for {
@@ -397,6 +780,45 @@ BODY_LOOP:
 	return expr, nil
 }
 
+// parseValues parses the rows of `VALUES (...), (...)` after the VALUES keyword values.
+func (p *Parser) parseValues(values *sqltoken.Token) (*sqlast.ConstructorSource, error) {
+	c := &sqlast.ConstructorSource{
+		Values: values.From,
+	}
+
+	for {
+		l, err := p.expectToken(sqltoken.LParen)
+		if err != nil {
+			return nil, err
+		}
+		var v []sqlast.Node
+		for {
+			e, err := p.parseExprOrDefault()
+			if err != nil {
+				return nil, errors.Errorf("parseExprOrDefault failed: %w", err)
+			}
+			v = append(v, e)
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		c.Rows = append(c.Rows, &sqlast.RowValueExpr{
+			Values: v,
+			LParen: l.From,
+			RParen: r.To,
+		})
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	return c, nil
+}
+
 func (p *Parser) parseSetOperator(token *sqltoken.Token) sqlast.SQLSetOperator {
 	if token == nil {
 		return nil
@@ -419,9 +841,13 @@ func (p *Parser) parseSetOperator(token *sqltoken.Token) sqlast.SQLSetOperator {
 }
 
 func (p *Parser) parseSelect() (*sqlast.SQLSelect, error) {
-	distinct, _, err := p.parseKeyword("DISTINCT")
+	distinct, err := p.parseSelectDistinct()
 	if err != nil {
-		return nil, errors.Errorf("parseKeyword failed: %w", err)
+		return nil, errors.Errorf("parseSelectDistinct failed: %w", err)
+	}
+	top, err := p.parseTop()
+	if err != nil {
+		return nil, errors.Errorf("parseTop failed: %w", err)
 	}
 	projection, err := p.parseSelectList()
 	if err != nil {
@@ -446,12 +872,27 @@ func (p *Parser) parseSelect() (*sqlast.SQLSelect, error) {
 	}
 
 	var groupBy []sqlast.Node
+	var groupByAll, groupByDistinct, withRollup bool
+	var withRollupTo sqltoken.Pos
 	if ok, _, _ := p.parseKeywords("GROUP", "BY"); ok {
-		g, err := p.parseExprList()
+		groupByAll, _, _ = p.parseKeyword("ALL")
+		if !groupByAll {
+			groupByDistinct, _, _ = p.parseKeyword("DISTINCT")
+		}
+
+		g, err := p.parseGroupingElementList()
 		if err != nil {
-			return nil, errors.Errorf("parseExprList failed: %w", err)
+			return nil, errors.Errorf("parseGroupingElementList failed: %w", err)
 		}
 		groupBy = g
+
+		if ok, toks, _ := p.parseKeywords("WITH", "ROLLUP"); ok {
+			if !p.dialect.Supports(dialect.WithRollup) {
+				return nil, p.unsupported(toks[0], "WITH ROLLUP")
+			}
+			withRollup = true
+			withRollupTo = toks[1].To
+		}
 	}
 
 	var having sqlast.Node
@@ -463,49 +904,262 @@ func (p *Parser) parseSelect() (*sqlast.SQLSelect, error) {
 		having = h
 	}
 
+	var windows []*sqlast.NamedWindow
+	if ok, _, _ := p.parseKeyword("WINDOW"); ok {
+		w, err := p.parseNamedWindowList()
+		if err != nil {
+			return nil, errors.Errorf("parseNamedWindowList failed: %w", err)
+		}
+		windows = w
+	}
+
 	return &sqlast.SQLSelect{
-		Distinct:      distinct,
-		Projection:    projection,
-		WhereClause:   selection,
-		FromClause:    tableRefs,
-		GroupByClause: groupBy,
-		HavingClause:  having,
+		Distinct:        distinct != nil && distinct.Type != sqlast.SelectAll,
+		DistinctClause:  distinct,
+		Top:             top,
+		Projection:      projection,
+		WhereClause:     selection,
+		FromClause:      tableRefs,
+		GroupByAll:      groupByAll,
+		GroupByDistinct: groupByDistinct,
+		GroupByClause:   groupBy,
+		WithRollup:      withRollup,
+		WithRollupTo:    withRollupTo,
+		HavingClause:    having,
+		WindowClause:    windows,
 	}, nil
 
 }
 
-func (p *Parser) parseSelectList() ([]sqlast.SQLSelectItem, error) {
-	var projections []sqlast.SQLSelectItem
+func (p *Parser) parseGroupingElementList() ([]sqlast.Node, error) {
+	var elements []sqlast.Node
 
 	for {
-		expr, err := p.ParseExpr()
+		e, err := p.parseGroupingElement()
 		if err != nil {
-			return nil, errors.Errorf("ParseExpr failed: %w", err)
+			return nil, errors.Errorf("parseGroupingElement failed: %w", err)
 		}
-		if w, ok := expr.(*sqlast.WildcardSelectItem); ok {
-			projections = append(projections, w)
-		} else if q, ok := expr.(*sqlast.QualifiedWildcard); ok {
-			projections = append(projections, &sqlast.QualifiedWildcardSelectItem{
-				Prefix: &sqlast.ObjectName{
-					Idents: q.Idents,
-				},
-			})
-		} else {
-			alias := p.parseOptionalAlias(dialect.ReservedForColumnAlias)
+		elements = append(elements, e)
 
-			if alias != nil {
-				projections = append(projections, &sqlast.AliasSelectItem{
-					Expr:  expr,
-					Alias: alias,
-				})
-			} else {
-				projections = append(projections, &sqlast.UnnamedSelectItem{
-					Node: expr,
-				})
-			}
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	return elements, nil
+}
+
+// parseGroupingElement parses an element of GROUP BY which is an expression,
+// ROLLUP (...), CUBE (...), GROUPING SETS (...) or a parenthesized list of expressions.
+func (p *Parser) parseGroupingElement() (sqlast.Node, error) {
+	tok, err := p.peekToken()
+	if err != nil {
+		return nil, errors.Errorf("peekToken failed: %w", err)
+	}
+
+	if tok.Kind == sqltoken.SQLKeyword && tok.Value.(*sqltoken.SQLWord).QuoteStyle == 0 {
+		switch tok.Value.(*sqltoken.SQLWord).Keyword {
+		case "ROLLUP", "CUBE":
+			// rollup(a) and cube(a) are plain function calls unless the dialect supports grouping sets
+			if !p.dialect.Supports(dialect.GroupingSets) {
+				break
+			}
+			p.mustNextToken()
+			if _, err := p.expectToken(sqltoken.LParen); err != nil {
+				return nil, err
+			}
+			elements, err := p.parseGroupingElementList()
+			if err != nil {
+				return nil, errors.Errorf("parseGroupingElementList failed: %w", err)
+			}
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
+			}
+			if tok.Value.(*sqltoken.SQLWord).Keyword == "ROLLUP" {
+				return &sqlast.Rollup{Rollup: tok.From, Elements: elements, RParen: r.To}, nil
+			}
+			return &sqlast.Cube{Cube: tok.From, Elements: elements, RParen: r.To}, nil
+		case "GROUPING":
+			ok, _, _ := p.parseKeywords("GROUPING", "SETS")
+			if !ok {
+				break
+			}
+			if !p.dialect.Supports(dialect.GroupingSets) {
+				return nil, p.unsupported(tok, "GROUPING SETS")
+			}
+			if _, err := p.expectToken(sqltoken.LParen); err != nil {
+				return nil, err
+			}
+			sets, err := p.parseGroupingElementList()
+			if err != nil {
+				return nil, errors.Errorf("parseGroupingElementList failed: %w", err)
+			}
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
+			}
+			return &sqlast.GroupingSets{Grouping: tok.From, Sets: sets, RParen: r.To}, nil
+		}
+	}
+
+	if tok.Kind == sqltoken.LParen {
+		return p.parseGroupingSet()
+	}
+
+	return p.ParseExpr()
+}
+
+// parseGroupingSet parses `()` and `(a, b)` as a GroupingSet.
+// A single parenthesized expression like `(a)` or `(a + b) * 2` is left to ParseExpr.
+func (p *Parser) parseGroupingSet() (sqlast.Node, error) {
+	idx := p.index
+	l := p.mustNextToken()
+
+	if r, _ := p.peekToken(); r != nil && r.Kind == sqltoken.RParen {
+		p.mustNextToken()
+		return &sqlast.GroupingSet{LParen: l.From, RParen: r.To}, nil
+	}
+
+	exprs, err := p.parseExprList()
+	if err == nil && len(exprs) > 1 {
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		return &sqlast.GroupingSet{LParen: l.From, RParen: r.To, Exprs: exprs}, nil
+	}
+
+	p.index = idx
+	return p.ParseExpr()
+}
+
+// parseSelectDistinct parses ALL, DISTINCT or DISTINCT ON (...) after SELECT.
+// It returns nil if there is none of them.
+func (p *Parser) parseSelectDistinct() (*sqlast.SelectDistinct, error) {
+	if ok, tok, err := p.parseKeyword("ALL"); err != nil {
+		return nil, errors.Errorf("parseKeyword failed: %w", err)
+	} else if ok {
+		return &sqlast.SelectDistinct{Type: sqlast.SelectAll, From: tok.From, To: tok.To}, nil
+	}
+
+	ok, tok, _ := p.parseKeyword("DISTINCT")
+	if !ok {
+		return nil, nil
+	}
+
+	on, onTok, _ := p.parseKeyword("ON")
+	if !on {
+		return &sqlast.SelectDistinct{Type: sqlast.SelectDistinctRows, From: tok.From, To: tok.To}, nil
+	}
+	if !p.dialect.Supports(dialect.DistinctOn) {
+		return nil, p.unsupported(onTok, "DISTINCT ON")
+	}
+
+	if _, err := p.expectToken(sqltoken.LParen); err != nil {
+		return nil, err
+	}
+	exprs, err := p.parseExprList()
+	if err != nil {
+		return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlast.SelectDistinct{
+		Type: sqlast.SelectDistinctOn,
+		On:   exprs,
+		From: tok.From,
+		To:   r.To,
+	}, nil
+}
+
+// parseTop parses TOP (n) [PERCENT] [WITH TIES] of T-SQL.
+// TOP which is not followed by '(' or a number is a column name.
+func (p *Parser) parseTop() (*sqlast.Top, error) {
+	if !p.dialect.Supports(dialect.Top) {
+		return nil, nil
+	}
+	ok, t, _ := p.parseKeyword("TOP")
+	if !ok {
+		return nil, nil
+	}
+	top := &sqlast.Top{Top: t.From}
+
+	if l, _ := p.peekToken(); l != nil && l.Kind == sqltoken.LParen {
+		p.mustNextToken()
+		expr, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		top.LParen = l.From
+		top.Expr = expr
+		top.To = r.To
+	} else if n, _ := p.peekToken(); n != nil && n.Kind == sqltoken.Number {
+		v, err := p.parseSQLValue()
+		if err != nil {
+			return nil, errors.Errorf("parseSQLValue failed: %w", err)
+		}
+		top.Expr = v
+		top.To = n.To
+	} else {
+		p.prevToken()
+		return nil, nil
+	}
+
+	if ok, t, _ := p.parseKeyword("PERCENT"); ok {
+		top.Percent = true
+		top.To = t.To
+	}
+	if ok, toks, _ := p.parseKeywords("WITH", "TIES"); ok {
+		top.WithTies = true
+		top.To = toks[1].To
+	}
+
+	return top, nil
+}
+
+func (p *Parser) parseSelectList() ([]sqlast.SQLSelectItem, error) {
+	var projections []sqlast.SQLSelectItem
+
+	for {
+		expr, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		if w, ok := expr.(*sqlast.WildcardSelectItem); ok {
+			projections = append(projections, w)
+		} else if q, ok := expr.(*sqlast.QualifiedWildcard); ok {
+			projections = append(projections, &sqlast.QualifiedWildcardSelectItem{
+				Prefix: &sqlast.ObjectName{
+					Idents: q.Idents,
+				},
+			})
+		} else {
+			alias, err := p.parseOptionalAlias(p.dialect.ReservedForColumnAlias())
+			if err != nil {
+				return nil, errors.Errorf("parseOptionalAlias failed: %w", err)
+			}
+
+			if alias != nil {
+				projections = append(projections, &sqlast.AliasSelectItem{
+					Expr:  expr,
+					Alias: alias,
+				})
+			} else {
+				projections = append(projections, &sqlast.UnnamedSelectItem{
+					Node: expr,
+				})
+			}
 		}
 
-		if t, _ := p.peekToken(); t.Kind == sqltoken.Comma {
+		if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.Comma {
 			p.mustNextToken()
 		} else {
 			break
@@ -517,12 +1171,15 @@ func (p *Parser) parseSelectList() ([]sqlast.SQLSelectItem, error) {
 func (p *Parser) parseCreate() (sqlast.Stmt, error) {
 	ok, t, _ := p.parseKeyword("CREATE")
 	if !ok {
-		return nil, errors.Errorf("expect CREATE but %+v", t)
+		return nil, p.expectedKeywords(t, "CREATE")
 	}
-	
-	vtok, _, _ := p.parseKeyword("VIRTUAL")
+
+	virtual, vtok, _ := p.parseKeyword("VIRTUAL")
 	if ok, _, _ := p.parseKeyword("TABLE"); ok {
-		if vtok {
+		if virtual {
+			if !p.dialect.Supports(dialect.VirtualTable) {
+				return nil, p.unsupported(vtok, "CREATE VIRTUAL TABLE")
+			}
 			return p.parseCreateVirtualTable(t)
 		}
 		return p.parseCreateTable(t)
@@ -543,9 +1200,41 @@ func (p *Parser) parseCreate() (sqlast.Stmt, error) {
 		return p.parseCreateIndex(uiok)
 	}
 
-	log.Panicln("TABLE or VIRTUAL TABLE or VIEW or UNIQUE INDEX or INDEX after create")
+	if p.rawStmt {
+		return p.parseRawStmt(t)
+	}
 
-	return nil, nil
+	tok, _ := p.peekToken()
+	return nil, p.expectedKeywords(tok, "TABLE", "VIRTUAL TABLE", "VIEW", "UNIQUE INDEX", "INDEX")
+}
+
+// parseRawStmt rewinds to start and keeps the source text until the next semicolon as RawStmt.
+func (p *Parser) parseRawStmt(start *sqltoken.Token) (sqlast.Stmt, error) {
+	if p.src == nil {
+		return nil, p.errorf(start, "source text is not available for unsupported statement")
+	}
+
+	// start has been consumed already
+	for p.tokens[p.index-1] != start {
+		p.index--
+	}
+	p.index--
+
+	end := start
+	for {
+		tok, err := p.peekToken()
+		if err == EOF || tok.Kind == sqltoken.Semicolon {
+			break
+		}
+		end = p.mustNextToken()
+	}
+
+	return &sqlast.RawStmt{
+		Keyword: strings.ToUpper(start.Value.(*sqltoken.SQLWord).Value),
+		Text:    string(p.src[start.FromOffset:end.ToOffset]),
+		From:    start.From,
+		To:      end.To,
+	}, nil
 }
 
 func (p *Parser) parseCreateTable(create *sqltoken.Token) (sqlast.Stmt, error) {
@@ -576,12 +1265,16 @@ func (p *Parser) parseCreateTable(create *sqltoken.Token) (sqlast.Stmt, error) {
 
 func (p *Parser) parseCreateView(create *sqltoken.Token) (sqlast.Stmt, error) {
 	materialized, _, _ := p.parseKeyword("MATERIALIZED")
-	p.expectKeyword("VIEW")
+	if _, err := p.expectKeyword("VIEW"); err != nil {
+		return nil, err
+	}
 	name, err := p.parseObjectName()
 	if err != nil {
 		return nil, errors.Errorf("parseObjectName failed: %w", err)
 	}
-	p.expectKeyword("AS")
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
 	q, err := p.parseQuery()
 	if err != nil {
 		return nil, errors.Errorf("parseQuery failed: %w", err)
@@ -605,7 +1298,9 @@ func (p *Parser) parseCreateIndex(unique bool) (sqlast.Stmt, error) {
 		} else {
 			indexName = n
 		}
-		p.expectKeyword("ON")
+		if _, err := p.expectKeyword("ON"); err != nil {
+			return nil, err
+		}
 	}
 
 	tableName, err := p.parseObjectName()
@@ -628,7 +1323,9 @@ func (p *Parser) parseCreateIndex(unique bool) (sqlast.Stmt, error) {
 		if err != nil {
 			return nil, errors.Errorf("parseColumnNames failed: %w", err)
 		}
-		p.expectToken(sqltoken.RParen)
+		if _, err := p.expectToken(sqltoken.RParen); err != nil {
+			return nil, err
+		}
 	}
 
 	var selection sqlast.Node
@@ -666,7 +1363,7 @@ func (p *Parser) parseCreateVirtualTable(create *sqltoken.Token) (sqlast.Stmt, e
 		using = moduleName
 	}
 
-	var args []string
+	args := []string{}
 	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
 		// Parse virtual table module arguments
 		for {
@@ -685,6 +1382,10 @@ func (p *Parser) parseCreateVirtualTable(create *sqltoken.Token) (sqlast.Stmt, e
 			p.mustNextToken()
 			if word, ok := tok.Value.(*sqltoken.SQLWord); ok {
 				args = append(args, word.String())
+			} else if tok.Kind == sqltoken.SingleQuotedString {
+				args = append(args, "'"+tok.Value.(string)+"'")
+			} else if str, ok := tok.Value.(*sqltoken.PrefixedString); ok {
+				args = append(args, str.String())
 			} else if str, ok := tok.Value.(string); ok {
 				args = append(args, str)
 			}
@@ -709,7 +1410,7 @@ func (p *Parser) parseElements() ([]sqlast.TableElement, error) {
 	for {
 		tok, _ := p.nextToken()
 		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("parse error after column def %+v", tok)
+			return nil, p.expected(tok, sqltoken.SQLKeyword)
 		}
 
 		word := tok.Value.(*sqltoken.SQLWord)
@@ -733,11 +1434,8 @@ func (p *Parser) parseElements() ([]sqlast.TableElement, error) {
 		}
 
 		t, _ := p.nextToken()
-		if t.Value == "ON" {
-			break
-		}
 		if t == nil || (t.Kind != sqltoken.Comma && t.Kind != sqltoken.RParen) {
-			log.Panicf("Expected ',' or ')' after column definition but %v", t)
+			return nil, p.expected(t, sqltoken.Comma, sqltoken.RParen)
 		} else if t.Kind == sqltoken.RParen {
 			break
 		}
@@ -747,8 +1445,10 @@ func (p *Parser) parseElements() ([]sqlast.TableElement, error) {
 }
 
 func (p *Parser) parseColumnDef() (*sqlast.ColumnDef, error) {
-	tok := p.mustNextToken()
-	columnName := tok.Value.(*sqltoken.SQLWord)
+	columnName, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
 
 	dataType, err := p.ParseDataType()
 	if err != nil {
@@ -761,12 +1461,8 @@ func (p *Parser) parseColumnDef() (*sqlast.ColumnDef, error) {
 	}
 
 	return &sqlast.ColumnDef{
-		Constraints: specs,
-		Name: &sqlast.Ident{
-			From:  tok.From,
-			To:    tok.To,
-			Value: columnName.String(),
-		},
+		Constraints:          specs,
+		Name:                 columnName,
 		MyDataTypeDecoration: decorates,
 		DataType:             dataType,
 		Default:              def,
@@ -776,7 +1472,7 @@ func (p *Parser) parseColumnDef() (*sqlast.ColumnDef, error) {
 func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
 	tok, _ := p.peekToken()
 	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
-		return nil, errors.Errorf("parse error after column def")
+		return nil, p.expected(tok, sqltoken.SQLKeyword)
 	}
 
 	word, ok := tok.Value.(*sqltoken.SQLWord)
@@ -794,6 +1490,9 @@ func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
 	}
 
 	tok, _ = p.peekToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return nil, p.expected(tok, sqltoken.SQLKeyword)
+	}
 
 	var spec sqlast.TableConstraintSpec
 	word = tok.Value.(*sqltoken.SQLWord)
@@ -803,14 +1502,16 @@ func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
 		if _, _, err := p.parseKeyword("KEY"); err != nil {
 			return nil, errors.Errorf("parseKeyword failed: %w", err)
 		}
-		p.expectToken(sqltoken.LParen)
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
 		columns, err := p.parseColumnNames()
 		if err != nil {
 			return nil, errors.Errorf("parseColumnNames failed: %w", err)
 		}
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
 		}
 		spec = &sqlast.UniqueTableConstraint{
 			Unique:  tok.From,
@@ -819,15 +1520,19 @@ func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
 		}
 	case "PRIMARY":
 		p.mustNextToken()
-		p.expectKeyword("KEY")
-		p.expectToken(sqltoken.LParen)
+		if _, err := p.expectKeyword("KEY"); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
 		columns, err := p.parseColumnNames()
 		if err != nil {
 			return nil, errors.Errorf("parseColumnNames failed: %w", err)
 		}
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
 		}
 		spec = &sqlast.UniqueTableConstraint{
 			Primary:   tok.From,
@@ -837,31 +1542,42 @@ func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
 		}
 	case "FOREIGN":
 		p.mustNextToken()
-		p.expectKeyword("KEY")
-		p.expectToken(sqltoken.LParen)
+		if _, err := p.expectKeyword("KEY"); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
 		columns, err := p.parseColumnNames()
 		if err != nil {
 			return nil, errors.Errorf("parseColumnNames failed: %w", err)
 		}
-		p.expectToken(sqltoken.RParen)
-		p.expectKeyword("REFERENCES")
+		if _, err := p.expectToken(sqltoken.RParen); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectKeyword("REFERENCES"); err != nil {
+			return nil, err
+		}
 
-		t, _ := p.nextToken()
-		w := t.Value.(*sqltoken.SQLWord)
-		p.expectToken(sqltoken.LParen)
+		tname, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
 		refcolumns, err := p.parseColumnNames()
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
+		if err != nil {
+			return nil, errors.Errorf("parseColumnNames failed: %w", err)
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
 		}
 		keys := &sqlast.ReferenceKeyExpr{
-			TableName: &sqlast.Ident{
-				From:  t.From,
-				To:    t.To,
-				Value: w.String(),
-			},
-			Columns: refcolumns,
-			RParen:  r.To,
+			TableName: tname,
+			Columns:   refcolumns,
+			RParen:    r.To,
 		}
 
 		spec = &sqlast.ReferentialTableConstraint{
@@ -871,14 +1587,16 @@ func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
 		}
 	case "CHECK":
 		p.mustNextToken()
-		p.expectToken(sqltoken.LParen)
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
 		expr, err := p.ParseExpr()
 		if err != nil {
 			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
 		}
 		spec = &sqlast.CheckTableConstraint{
 			Expr:   expr,
@@ -886,7 +1604,7 @@ func (p *Parser) parseTableConstraints() (*sqlast.TableConstraint, error) {
 			RParen: r.To,
 		}
 	default:
-		return nil, errors.Errorf("unknown table constraint: %v", word)
+		return nil, p.expectedKeywords(tok, "PRIMARY", "UNIQUE", "FOREIGN", "CHECK")
 	}
 
 	return &sqlast.TableConstraint{
@@ -926,12 +1644,16 @@ COLUMN_DEF_LOOP:
 			if err != nil {
 				return nil, nil, nil, errors.Errorf("parseColumnConstraints failed: %w", err)
 			}
-			specs = s
+			specs = append(specs, s...)
 		case "AUTOINCREMENT", "AUTO_INCREMENT":
+			if !p.dialect.Supports(dialect.AutoIncrement) {
+				return nil, nil, nil, p.unsupported(t, word.Keyword)
+			}
 			p.mustNextToken()
 			decorates = append(decorates, &sqlast.AutoIncrement{
 				Auto:      t.From,
 				Increment: t.To,
+				SQLite:    word.Keyword == "AUTOINCREMENT",
 			})
 		default:
 			break COLUMN_DEF_LOOP
@@ -965,7 +1687,10 @@ CONSTRAINT_LOOP:
 		}
 
 		tok, _ = p.peekToken()
-		if tok.Kind != sqltoken.SQLKeyword {
+		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+			if name != nil {
+				return nil, p.expected(tok, sqltoken.SQLKeyword)
+			}
 			break
 		}
 
@@ -977,7 +1702,7 @@ CONSTRAINT_LOOP:
 			p.mustNextToken()
 			ok, ntok, _ := p.parseKeyword("NULL")
 			if !ok {
-				return nil, errors.Errorf("expected NULL but +%v", ntok)
+				return nil, p.expectedKeywords(ntok, "NULL")
 			}
 			spec = &sqlast.NotNullColumnSpec{
 				Not:  tok.From,
@@ -992,7 +1717,7 @@ CONSTRAINT_LOOP:
 			p.mustNextToken()
 			ok, ktok, _ := p.parseKeyword("KEY")
 			if !ok {
-				return nil, errors.Errorf("expected KEY but +%v", ktok)
+				return nil, p.expectedKeywords(ktok, "KEY")
 			}
 			spec = &sqlast.UniqueColumnSpec{IsPrimaryKey: true, Primary: tok.From, Key: ktok.To}
 		case "REFERENCES":
@@ -1001,38 +1726,42 @@ CONSTRAINT_LOOP:
 			if err != nil {
 				return nil, errors.Errorf("parseObjectName failed: %w", err)
 			}
-			p.expectToken(sqltoken.LParen)
+			if _, err := p.expectToken(sqltoken.LParen); err != nil {
+				return nil, err
+			}
 			columns, err := p.parseColumnNames()
 			if err != nil {
 				return nil, errors.Errorf("parseColumnNames failed: %w", err)
 			}
-			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
-				return nil, errors.Errorf("expected RParen but %+v", r)
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
 			}
 
-			// TODO: if we encounter `REFERENCES` hardcode
-			// skip 3 tokens
-			p.nextToken()
-			p.nextToken()
-			p.nextToken()
+			actions, err := p.parseReferentialActions()
+			if err != nil {
+				return nil, errors.Errorf("parseReferentialActions failed: %w", err)
+			}
 
 			spec = &sqlast.ReferencesColumnSpec{
 				TableName:  tname,
 				Columns:    columns,
 				References: tok.From,
 				RParen:     r.To,
+				Actions:    actions,
 			}
 		case "CHECK":
 			p.mustNextToken()
-			p.expectToken(sqltoken.LParen)
+			if _, err := p.expectToken(sqltoken.LParen); err != nil {
+				return nil, err
+			}
 			expr, err := p.ParseExpr()
 			if err != nil {
 				return nil, errors.Errorf("ParseExpr failed: %w", err)
 			}
-			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
-				return nil, errors.Errorf("expected RParen but %+v", r)
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
 			}
 			spec = &sqlast.CheckColumnSpec{
 				Check:  tok.From,
@@ -1052,6 +1781,48 @@ CONSTRAINT_LOOP:
 	return constraints, nil
 }
 
+func (p *Parser) parseReferentialActions() ([]*sqlast.ReferentialAction, error) {
+	var actions []*sqlast.ReferentialAction
+
+	for {
+		ok, on, _ := p.parseKeyword("ON")
+		if !ok {
+			break
+		}
+
+		event, err := p.nextToken()
+		if err != nil {
+			return nil, errors.Errorf("nextToken failed: %w", err)
+		}
+		word, ok := event.Value.(*sqltoken.SQLWord)
+		if !ok || (word.Keyword != "DELETE" && word.Keyword != "UPDATE") {
+			return nil, p.expectedKeywords(event, "DELETE", "UPDATE")
+		}
+
+		action := &sqlast.ReferentialAction{
+			On:    on.From,
+			Event: word.Keyword,
+		}
+		if ok, toks, _ := p.parseKeywords("SET", "NULL"); ok {
+			action.Action, action.To = "SET NULL", toks[1].To
+		} else if ok, toks, _ := p.parseKeywords("SET", "DEFAULT"); ok {
+			action.Action, action.To = "SET DEFAULT", toks[1].To
+		} else if ok, toks, _ := p.parseKeywords("NO", "ACTION"); ok {
+			action.Action, action.To = "NO ACTION", toks[1].To
+		} else if ok, t, _ := p.parseKeyword("CASCADE"); ok {
+			action.Action, action.To = "CASCADE", t.To
+		} else if ok, t, _ := p.parseKeyword("RESTRICT"); ok {
+			action.Action, action.To = "RESTRICT", t.To
+		} else {
+			t, _ := p.peekToken()
+			return nil, p.expectedKeywords(t, "CASCADE", "RESTRICT", "SET NULL", "SET DEFAULT", "NO ACTION")
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
 func (p *Parser) parseTableOptions() ([]sqlast.TableOption, error) {
 	var opts []sqlast.TableOption
 
@@ -1064,14 +1835,16 @@ func (p *Parser) parseTableOptions() ([]sqlast.TableOption, error) {
 		}
 
 		if tok.Kind == sqltoken.Comma {
-			tok = p.mustNextToken()
+			p.mustNextToken()
+			if tok, err = p.peekToken(); err != nil {
+				return nil, err
+			}
 		}
 		if tok.Kind != sqltoken.SQLKeyword {
 			break
 		}
 		opt, err := p.parseTableOption()
 		if err != nil {
-			p.Debug()
 			return nil, errors.Errorf("parseTableOption failed: %w", err)
 		}
 		opts = append(opts, opt)
@@ -1082,26 +1855,51 @@ func (p *Parser) parseTableOptions() ([]sqlast.TableOption, error) {
 
 func (p *Parser) parseTableOption() (sqlast.TableOption, error) {
 	tok, _ := p.peekToken()
-	if tok.Kind != sqltoken.SQLKeyword {
-		return nil, errors.Errorf("must be SQLKeyword but: %v", tok)
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return nil, p.expected(tok, sqltoken.SQLKeyword)
 	}
 	word, _ := tok.Value.(*sqltoken.SQLWord)
 
+	switch word.Keyword {
+	case "WITHOUT", "STRICT":
+		if !p.dialect.Supports(dialect.WithoutRowID) {
+			return nil, p.unsupported(tok, "table option")
+		}
+	default:
+		if !p.dialect.Supports(dialect.TableOptions) {
+			return nil, p.unsupported(tok, "table option")
+		}
+	}
+
 	p.mustNextToken()
 	switch word.Keyword {
+	case "WITHOUT":
+		r, err := p.expectKeyword("ROWID")
+		if err != nil {
+			return nil, err
+		}
+		return &sqlast.WithoutRowID{
+			Without: tok.From,
+			RowID:   r.To,
+		}, nil
+	case "STRICT":
+		return &sqlast.Strict{
+			From: tok.From,
+			To:   tok.To,
+		}, nil
 	case "ENGINE":
 		opt := &sqlast.MyEngine{
 			Engine: tok.From,
 		}
 		t, _ := p.peekToken()
-		if t.Kind == sqltoken.Eq {
+		if t != nil && t.Kind == sqltoken.Eq {
 			opt.Equal = true
 			p.mustNextToken()
 			t, _ = p.peekToken()
 		}
 
-		if t.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("expected '=' or 'engine_name' but: %v", t)
+		if t == nil || t.Kind != sqltoken.SQLKeyword {
+			return nil, p.errorf(t, "expected '=' or engine name but found %s", tokenString(t))
 		}
 		name, _ := p.parseIdentifier()
 		opt.Name = name
@@ -1113,19 +1911,19 @@ func (p *Parser) parseTableOption() (sqlast.TableOption, error) {
 		}
 		ok, t, err := p.parseKeyword("CHARSET")
 		if !ok || err != nil {
-			return nil, errors.Errorf("expected CHARSET but: %v", t)
+			return nil, p.expectedKeywords(t, "CHARSET")
 		}
 		opt.Charset = t.From
 
 		t, _ = p.peekToken()
-		if t.Kind == sqltoken.Eq {
+		if t != nil && t.Kind == sqltoken.Eq {
 			opt.Equal = true
 			p.mustNextToken()
 			t, _ = p.peekToken()
 		}
 
-		if t.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("expected '=' or 'charset_name' but: %v", t)
+		if t == nil || t.Kind != sqltoken.SQLKeyword {
+			return nil, p.errorf(t, "expected '=' or charset name but found %s", tokenString(t))
 		}
 
 		name, _ := p.parseIdentifier()
@@ -1138,14 +1936,14 @@ func (p *Parser) parseTableOption() (sqlast.TableOption, error) {
 			Charset: tok.From,
 		}
 		t, _ := p.peekToken()
-		if t.Kind == sqltoken.Eq {
+		if t != nil && t.Kind == sqltoken.Eq {
 			opt.Equal = true
 			p.mustNextToken()
 			t, _ = p.peekToken()
 		}
 
-		if t.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("expected '=' or 'charset_name' but: %v", t)
+		if t == nil || t.Kind != sqltoken.SQLKeyword {
+			return nil, p.errorf(t, "expected '=' or charset name but found %s", tokenString(t))
 		}
 
 		name, _ := p.parseIdentifier()
@@ -1153,22 +1951,29 @@ func (p *Parser) parseTableOption() (sqlast.TableOption, error) {
 
 		return opt, nil
 	default:
-		return nil, errors.Errorf("unsupported Table Options: %v", word)
+		return nil, p.errorf(tok, "unsupported table option %s", word.Keyword)
 	}
 }
 
 func (p *Parser) parseDelete() (sqlast.Stmt, error) {
 	ok, d, _ := p.parseKeyword("DELETE")
 	if !ok {
-		return nil, errors.Errorf("expect DELETE but %+v", d)
+		return nil, p.expectedKeywords(d, "DELETE")
 	}
 
-	p.expectKeyword("FROM")
+	if _, err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
 	tableName, err := p.parseObjectName()
 	if err != nil {
 		return nil, errors.Errorf("parseObjectName failed: %w", err)
 	}
 
+	output, err := p.parseOutputClause()
+	if err != nil {
+		return nil, errors.Errorf("parseOutputClause failed: %w", err)
+	}
+
 	var selection sqlast.Node
 	if ok, _, _ := p.parseKeyword("WHERE"); ok {
 		selection, err = p.ParseExpr()
@@ -1177,29 +1982,43 @@ func (p *Parser) parseDelete() (sqlast.Stmt, error) {
 		}
 	}
 
+	returning, err := p.parseReturningClause()
+	if err != nil {
+		return nil, errors.Errorf("parseReturningClause failed: %w", err)
+	}
+
 	return &sqlast.DeleteStmt{
 		Delete:    d.From,
 		TableName: tableName,
+		Output:    output,
 		Selection: selection,
+		Returning: returning,
 	}, nil
 }
 
 func (p *Parser) parseUpdate() (sqlast.Stmt, error) {
 	ok, u, _ := p.parseKeyword("UPDATE")
 	if !ok {
-		return nil, errors.Errorf("expect UPDATE but %+v", ok)
+		return nil, p.expectedKeywords(u, "UPDATE")
 	}
 	tableName, err := p.parseObjectName()
 	if err != nil {
 		return nil, errors.Errorf("parseObjectName failed: %w", err)
 	}
-	p.expectKeyword("SET")
+	if _, err := p.expectKeyword("SET"); err != nil {
+		return nil, err
+	}
 
 	assignments, err := p.parseAssignments()
 	if err != nil {
 		return nil, errors.Errorf("parseAssignments failed: %w", err)
 	}
 
+	output, err := p.parseOutputClause()
+	if err != nil {
+		return nil, errors.Errorf("parseOutputClause failed: %w", err)
+	}
+
 	var selection sqlast.Node
 	if ok, _, _ := p.parseKeyword("WHERE"); ok {
 		selection, err = p.ParseExpr()
@@ -1208,31 +2027,130 @@ func (p *Parser) parseUpdate() (sqlast.Stmt, error) {
 		}
 	}
 
+	returning, err := p.parseReturningClause()
+	if err != nil {
+		return nil, errors.Errorf("parseReturningClause failed: %w", err)
+	}
+
 	return &sqlast.UpdateStmt{
 		Update:      u.From,
 		TableName:   tableName,
 		Assignments: assignments,
+		Output:      output,
 		Selection:   selection,
+		Returning:   returning,
 	}, nil
 
 }
 
+// parseOutputClause parses OUTPUT clause of T-SQL INSERT, UPDATE and DELETE.
+func (p *Parser) parseOutputClause() (*sqlast.OutputClause, error) {
+	ok, o, _ := p.parseKeyword("OUTPUT")
+	if !ok {
+		return nil, nil
+	}
+	if !p.dialect.Supports(dialect.OutputClause) {
+		return nil, p.unsupported(o, "OUTPUT")
+	}
+
+	items, err := p.parseSelectList()
+	if err != nil {
+		return nil, errors.Errorf("parseSelectList failed: %w", err)
+	}
+	output := &sqlast.OutputClause{
+		Output: o.From,
+		Items:  items,
+	}
+
+	if ok, _, _ := p.parseKeyword("INTO"); ok {
+		into, err := p.parseObjectName()
+		if err != nil {
+			return nil, errors.Errorf("parseObjectName failed: %w", err)
+		}
+		output.Into = into
+
+		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+			columns, err := p.parseColumnNames()
+			if err != nil {
+				return nil, errors.Errorf("parseColumnNames failed: %w", err)
+			}
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
+			}
+			output.IntoColumns = columns
+			output.RParen = r.To
+		}
+	}
+
+	return output, nil
+}
+
+// parseExprOrDefault parses an expression or DEFAULT in VALUES rows and SET assignments.
+func (p *Parser) parseExprOrDefault() (sqlast.Node, error) {
+	if ok, tok, _ := p.parseKeyword("DEFAULT"); ok {
+		// DEFAULT(col) is a function of MySQL
+		if t, _ := p.peekToken(); t == nil || t.Kind != sqltoken.LParen {
+			return &sqlast.DefaultValue{
+				From: tok.From,
+				To:   tok.To,
+			}, nil
+		}
+		p.prevToken()
+	}
+	return p.ParseExpr()
+}
+
+// parseReturningClause parses RETURNING clause of INSERT, UPDATE and DELETE.
+func (p *Parser) parseReturningClause() (*sqlast.ReturningClause, error) {
+	ok, r, _ := p.parseKeyword("RETURNING")
+	if !ok {
+		return nil, nil
+	}
+	if !p.dialect.Supports(dialect.Returning) {
+		return nil, p.unsupported(r, "RETURNING")
+	}
+
+	items, err := p.parseSelectList()
+	if err != nil {
+		return nil, errors.Errorf("parseSelectList failed: %w", err)
+	}
+
+	return &sqlast.ReturningClause{
+		Returning: r.From,
+		Items:     items,
+	}, nil
+}
+
 func (p *Parser) parseAssignments() ([]*sqlast.Assignment, error) {
 	var assignments []*sqlast.Assignment
 
 	for {
 		tok, _ := p.nextToken()
-		if tok.Kind != sqltoken.SQLKeyword {
-			return nil, errors.Errorf("should be sqlkeyword but %v", tok)
+		if tok != nil && tok.Kind == sqltoken.LParen {
+			a, err := p.parseMultiColumnAssignment(tok)
+			if err != nil {
+				return nil, errors.Errorf("parseMultiColumnAssignment failed: %w", err)
+			}
+			assignments = append(assignments, a)
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+			continue
+		}
+		if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+			return nil, p.expected(tok, sqltoken.SQLKeyword)
 		}
 
 		word := tok.Value.(*sqltoken.SQLWord)
 
-		p.expectToken(sqltoken.Eq)
+		if _, err := p.expectToken(sqltoken.Eq); err != nil {
+			return nil, err
+		}
 
-		val, err := p.ParseExpr()
+		val, err := p.parseExprOrDefault()
 		if err != nil {
-			return nil, errors.Errorf("ParseExpr failed: %w", err)
+			return nil, errors.Errorf("parseExprOrDefault failed: %w", err)
 		}
 
 		assignments = append(assignments, &sqlast.Assignment{
@@ -1248,30 +2166,159 @@ func (p *Parser) parseAssignments() ([]*sqlast.Assignment, error) {
 	return assignments, nil
 }
 
+// parseMultiColumnAssignment parses `(a, b) = (1, DEFAULT)` or `(a, b) = (SELECT ...)`
+// after the left parenthesis l.
+func (p *Parser) parseMultiColumnAssignment(l *sqltoken.Token) (*sqlast.Assignment, error) {
+	if !p.dialect.Supports(dialect.MultiColumnAssignment) {
+		return nil, p.unsupported(l, "SET (column, ...) = ...")
+	}
+	columns, err := p.parseColumnNames()
+	if err != nil {
+		return nil, errors.Errorf("parseColumnNames failed: %w", err)
+	}
+	if _, err := p.expectToken(sqltoken.RParen); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectToken(sqltoken.Eq); err != nil {
+		return nil, err
+	}
+
+	a := &sqlast.Assignment{
+		Columns: columns,
+		LParen:  l.From,
+	}
+
+	r, err := p.expectToken(sqltoken.LParen)
+	if err != nil {
+		return nil, err
+	}
+	if ok, _, _ := p.parseKeyword("SELECT"); ok {
+		p.prevToken()
+	} else if ok, _, _ := p.parseKeyword("WITH"); ok {
+		p.prevToken()
+	} else {
+		var values []sqlast.Node
+		for {
+			v, err := p.parseExprOrDefault()
+			if err != nil {
+				return nil, errors.Errorf("parseExprOrDefault failed: %w", err)
+			}
+			values = append(values, v)
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+		rp, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		a.Value = &sqlast.RowValueExpr{
+			Values: values,
+			LParen: r.From,
+			RParen: rp.To,
+		}
+		return a, nil
+	}
+
+	// (SELECT ...) is a sub query
+	p.prevToken()
+	a.Value, err = p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	return a, nil
+}
+
 func (p *Parser) parseInsert() (sqlast.Stmt, error) {
-	ok, i, _ := p.parseKeyword("INSERT")
-	if !ok {
-		return nil, errors.Errorf("expected INSERT but %+v", i)
+	i, err := p.nextToken()
+	if err != nil {
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+	word, _ := i.Value.(*sqltoken.SQLWord)
+	if word == nil || (word.Keyword != "INSERT" && word.Keyword != "REPLACE") {
+		return nil, p.expectedKeywords(i, "INSERT", "REPLACE")
+	}
+	replace := word.Keyword == "REPLACE"
+
+	var or string
+	var ignore bool
+	if !replace {
+		if ok, tok, _ := p.parseKeyword("IGNORE"); ok {
+			if !p.dialect.Supports(dialect.InsertIgnore) {
+				return nil, p.unsupported(tok, "INSERT IGNORE")
+			}
+			ignore = true
+		} else if or, err = p.parseInsertOr(); err != nil {
+			return nil, err
+		}
 	}
 
-	p.expectKeyword("INTO")
+	if _, err := p.expectKeyword("INTO"); err != nil {
+		return nil, err
+	}
 	tableName, err := p.parseObjectName()
 
 	if err != nil {
 		return nil, errors.Errorf("invalid table name: %w", err)
 	}
+
+	var alias *sqlast.Ident
+	if ok, tok, _ := p.parseKeyword("AS"); ok {
+		if !p.dialect.Supports(dialect.OnConflict) {
+			return nil, p.unsupported(tok, "alias of INSERT target")
+		}
+		alias, err = p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+	}
+
 	var columns []*sqlast.Ident
 
-	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+	if p.peekColumnList() {
+		p.mustNextToken()
 		columns, err = p.parseColumnNames()
 		if err != nil {
 			return nil, errors.Errorf("invalid column names: %w", err)
 		}
-		p.expectToken(sqltoken.RParen)
+		if _, err := p.expectToken(sqltoken.RParen); err != nil {
+			return nil, err
+		}
+	}
+
+	output, err := p.parseOutputClause()
+	if err != nil {
+		return nil, errors.Errorf("parseOutputClause failed: %w", err)
 	}
 
 	var insertSrc sqlast.InsertSource
-	if ok, _, _ := p.parseKeyword("VALUES"); !ok {
+	if ok, toks, _ := p.parseKeywords("DEFAULT", "VALUES"); ok {
+		if !p.dialect.Supports(dialect.DefaultValues) {
+			return nil, p.unsupported(toks[0], "DEFAULT VALUES")
+		}
+		insertSrc = &sqlast.DefaultValuesSource{
+			Default: toks[0].From,
+			Values:  toks[1].To,
+		}
+	} else if ok, tok, _ := p.parseKeyword("SET"); ok {
+		if !p.dialect.Supports(dialect.InsertSet) {
+			return nil, p.unsupported(tok, "INSERT ... SET")
+		}
+		assignments, err := p.parseAssignments()
+		if err != nil {
+			return nil, errors.Errorf("parseAssignments failed: %w", err)
+		}
+		insertSrc = &sqlast.SetSource{
+			Set:         tok.From,
+			Assignments: assignments,
+		}
+	} else if ok, tok, _ := p.parseKeyword("VALUES"); ok {
+		constSrc, err := p.parseValues(tok)
+		if err != nil {
+			return nil, errors.Errorf("invalid insert value assign: %w", err)
+		}
+		insertSrc = constSrc
+	} else {
 		q, err := p.parseQuery()
 		if err != nil {
 			return nil, errors.Errorf("invalid select source: expected query: %w", err)
@@ -1279,36 +2326,13 @@ func (p *Parser) parseInsert() (sqlast.Stmt, error) {
 		insertSrc = &sqlast.SubQuerySource{
 			SubQuery: q,
 		}
-	} else {
-		var constSrc sqlast.ConstructorSource
-		for {
-			l, _ := p.nextToken()
-			if l.Kind != sqltoken.LParen {
-				return nil, errors.Errorf("expected LParen but %+v", l)
-			}
-			v, err := p.parseExprList()
-			if err != nil {
-				return nil, errors.Errorf("invalid insert value assign: %w", err)
-			}
-			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
-				return nil, errors.Errorf("expected RParen but %+v", r)
-			}
-			constSrc.Rows = append(constSrc.Rows, &sqlast.RowValueExpr{
-				Values: v,
-				LParen: l.From,
-				RParen: r.To,
-			})
-			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
-				break
-			}
-		}
-
-		insertSrc = &constSrc
 	}
 
 	var assigns []*sqlast.Assignment
-	if ok, _, _ := p.parseKeywords("ON", "DUPLICATE", "KEY", "UPDATE"); ok {
+	if ok, toks, _ := p.parseKeywords("ON", "DUPLICATE", "KEY", "UPDATE"); ok {
+		if !p.dialect.Supports(dialect.OnDuplicateKeyUpdate) {
+			return nil, p.unsupported(toks[0], "ON DUPLICATE KEY UPDATE")
+		}
 		assignments, err := p.parseAssignments()
 		if err != nil {
 			return nil, errors.Errorf("invalid DUPLICATE KEY UPDATE assignments: %w", err)
@@ -1316,134 +2340,346 @@ func (p *Parser) parseInsert() (sqlast.Stmt, error) {
 		assigns = assignments
 	}
 
+	onConflict, err := p.parseOnConflict()
+	if err != nil {
+		return nil, errors.Errorf("parseOnConflict failed: %w", err)
+	}
+
+	returning, err := p.parseReturningClause()
+	if err != nil {
+		return nil, errors.Errorf("parseReturningClause failed: %w", err)
+	}
+
 	return &sqlast.InsertStmt{
 		Insert:            i.From,
+		Replace:           replace,
+		Ignore:            ignore,
+		Or:                or,
 		TableName:         tableName,
+		Alias:             alias,
 		Columns:           columns,
+		Output:            output,
 		Source:            insertSrc,
 		UpdateAssignments: assigns,
+		OnConflict:        onConflict,
+		Returning:         returning,
 	}, nil
 }
 
-func (p *Parser) parseAlter() (sqlast.Stmt, error) {
-	ok, tok, _ := p.parseKeyword("ALTER")
+// parseOnConflict parses
+// ON CONFLICT [(target, ...) [WHERE predicate] | ON CONSTRAINT name] DO NOTHING | DO UPDATE SET ... [WHERE condition]
+// of INSERT.
+func (p *Parser) parseOnConflict() (*sqlast.OnConflict, error) {
+	ok, toks, _ := p.parseKeywords("ON", "CONFLICT")
 	if !ok {
-		return nil, errors.Errorf("expected ALTER but %s", tok)
+		return nil, nil
+	}
+	if !p.dialect.Supports(dialect.OnConflict) {
+		return nil, p.unsupported(toks[0], "ON CONFLICT")
 	}
 
-	p.expectKeyword("TABLE")
-
-	tableName, err := p.parseObjectName()
-	if err != nil {
-		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	c := &sqlast.OnConflict{
+		On: toks[0].From,
 	}
 
-	if ok, toks, _ := p.parseKeywords("ADD", "COLUMN"); ok {
-		columnDef, err := p.parseColumnDef()
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		target, err := p.parseExprList()
 		if err != nil {
-			return nil, errors.Errorf("parseColumnDef failed: %w", err)
+			return nil, errors.Errorf("parseExprList failed: %w", err)
 		}
-
-		return &sqlast.AlterTableStmt{
-			TableName: tableName,
-			Alter:     tok.From,
-			Action: &sqlast.AddColumnTableAction{
-				Add:    toks[0].From,
-				Column: columnDef,
-			},
-		}, nil
-	}
-
-	if ok, add, _ := p.parseKeyword("ADD"); ok {
-		constraint, err := p.parseTableConstraints()
+		r, err := p.expectToken(sqltoken.RParen)
 		if err != nil {
-			return nil, errors.Errorf("parseTableConstraints failed: %w", err)
+			return nil, err
 		}
+		c.Target = target
+		c.TargetRParen = r.To
 
-		return &sqlast.AlterTableStmt{
-			TableName: tableName,
-			Alter:     tok.From,
-			Action: &sqlast.AddConstraintTableAction{
-				Add:        add.From,
-				Constraint: constraint,
-			},
-		}, nil
-	}
-
-	if ok, toks, _ := p.parseKeywords("DROP", "CONSTRAINT"); ok {
-		constraintName, err := p.parseIdentifier()
+		if ok, _, _ := p.parseKeyword("WHERE"); ok {
+			c.TargetWhere, err = p.ParseExpr()
+			if err != nil {
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+		}
+	} else if ok, _, _ := p.parseKeywords("ON", "CONSTRAINT"); ok {
+		name, err := p.parseIdentifier()
 		if err != nil {
 			return nil, errors.Errorf("parseIdentifier failed: %w", err)
 		}
-		var caspos sqltoken.Pos
-		cascade, t, _ := p.parseKeyword("CASCADE")
-		if cascade {
-			caspos = t.To
-		}
+		c.Constraint = name
+	}
 
-		return &sqlast.AlterTableStmt{
-			TableName: tableName,
-			Alter:     tok.From,
-			Action: &sqlast.DropConstraintTableAction{
-				Drop:       toks[0].From,
-				Name:       constraintName,
-				Cascade:    cascade,
-				CascadePos: caspos,
-			},
-		}, nil
+	if _, err := p.expectKeyword("DO"); err != nil {
+		return nil, err
 	}
 
-	if ok, toks, _ := p.parseKeywords("DROP", "COLUMN"); ok {
-		constraintName, err := p.parseIdentifier()
-		if err != nil {
-			return nil, errors.Errorf("parseIdentifier failed: %w", err)
-		}
-		var caspos sqltoken.Pos
-		cascade, t, _ := p.parseKeyword("CASCADE")
-		if cascade {
-			caspos = t.To
-		}
+	if ok, nothing, _ := p.parseKeyword("NOTHING"); ok {
+		c.DoNothing = true
+		c.Nothing = nothing.To
+		return c, nil
+	}
 
-		return &sqlast.AlterTableStmt{
-			TableName: tableName,
-			Alter:     tok.From,
-			Action: &sqlast.RemoveColumnTableAction{
-				Name:       constraintName,
-				Drop:       toks[0].From,
-				Cascade:    cascade,
-				CascadePos: caspos,
-			},
-		}, nil
+	if ok, tok, _ := p.parseKeyword("UPDATE"); !ok {
+		return nil, p.expectedKeywords(tok, "NOTHING", "UPDATE")
+	}
+	if _, err := p.expectKeyword("SET"); err != nil {
+		return nil, err
+	}
+	assignments, err := p.parseAssignments()
+	if err != nil {
+		return nil, errors.Errorf("parseAssignments failed: %w", err)
 	}
+	c.Assignments = assignments
 
-	if ok, toks, _ := p.parseKeywords("ALTER", "COLUMN"); ok {
-		action, err := p.parseAlterColumn(toks[0])
+	if ok, _, _ := p.parseKeyword("WHERE"); ok {
+		c.Selection, err = p.ParseExpr()
 		if err != nil {
-			return nil, errors.Errorf("parseAlterColumn failed: %w", err)
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
+	}
 
-		return &sqlast.AlterTableStmt{
-			Alter:     tok.From,
-			TableName: tableName,
-			Action:    action,
-		}, nil
+	return c, nil
+}
+
+// peekColumnList reports whether the next LParen starts the column list of INSERT,
+// not a parenthesized query like `INSERT INTO t (SELECT ...)`.
+func (p *Parser) peekColumnList() bool {
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		return false
+	}
+	defer p.prevToken()
+
+	tok, _ := p.peekToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return true
+	}
+	switch tok.Value.(*sqltoken.SQLWord).Keyword {
+	case "SELECT", "WITH", "VALUES":
+		return false
+	}
+	return true
+}
+
+// parseInsertOr parses the conflict resolution of SQLite INSERT OR ... statement.
+func (p *Parser) parseInsertOr() (string, error) {
+	ok, t, _ := p.parseKeyword("OR")
+	if !ok {
+		return "", nil
+	}
+	if !p.dialect.Supports(dialect.InsertOr) {
+		return "", p.unsupported(t, "INSERT OR")
+	}
+
+	a, err := p.nextToken()
+	if err != nil {
+		return "", errors.Errorf("nextToken failed: %w", err)
+	}
+	if action, ok := a.Value.(*sqltoken.SQLWord); ok {
+		switch action.Keyword {
+		case "REPLACE", "IGNORE", "ABORT", "FAIL", "ROLLBACK":
+			return action.Keyword, nil
+		}
+	}
+	return "", p.expectedKeywords(a, "REPLACE", "IGNORE", "ABORT", "FAIL", "ROLLBACK")
+}
+
+func (p *Parser) parsePragma(pragma *sqltoken.Token) (sqlast.Stmt, error) {
+	name, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+	stmt := &sqlast.PragmaStmt{
+		Pragma: pragma.From,
+		Name:   name,
+	}
+
+	if ok, _ := p.consumeToken(sqltoken.Eq); ok {
+		v, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		stmt.Value = v
+	} else if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		v, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		stmt.Value = v
+		stmt.Call = true
+		stmt.RParen = r.To
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseAttach(attach *sqltoken.Token) (sqlast.Stmt, error) {
+	database, _, _ := p.parseKeyword("DATABASE")
+	file, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	schema, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	return &sqlast.AttachStmt{
+		Attach:   attach.From,
+		Database: database,
+		File:     file,
+		Schema:   schema,
+	}, nil
+}
+
+func (p *Parser) parseDetach(detach *sqltoken.Token) (sqlast.Stmt, error) {
+	database, _, _ := p.parseKeyword("DATABASE")
+	schema, err := p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	return &sqlast.DetachStmt{
+		Detach:   detach.From,
+		Database: database,
+		Schema:   schema,
+	}, nil
+}
+
+func (p *Parser) parseAlter() (sqlast.Stmt, error) {
+	ok, tok, _ := p.parseKeyword("ALTER")
+	if !ok {
+		return nil, p.expectedKeywords(tok, "ALTER")
+	}
+
+	if _, err := p.expectKeyword("TABLE"); err != nil {
+		if p.rawStmt {
+			return p.parseRawStmt(tok)
+		}
+		return nil, err
+	}
+
+	tableName, err := p.parseObjectName()
+	if err != nil {
+		return nil, errors.Errorf("parseObjectName failed: %w", err)
+	}
+
+	if ok, toks, _ := p.parseKeywords("ADD", "COLUMN"); ok {
+		columnDef, err := p.parseColumnDef()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnDef failed: %w", err)
+		}
+
+		return &sqlast.AlterTableStmt{
+			TableName: tableName,
+			Alter:     tok.From,
+			Action: &sqlast.AddColumnTableAction{
+				Add:    toks[0].From,
+				Column: columnDef,
+			},
+		}, nil
+	}
+
+	if ok, add, _ := p.parseKeyword("ADD"); ok {
+		constraint, err := p.parseTableConstraints()
+		if err != nil {
+			return nil, errors.Errorf("parseTableConstraints failed: %w", err)
+		}
+
+		return &sqlast.AlterTableStmt{
+			TableName: tableName,
+			Alter:     tok.From,
+			Action: &sqlast.AddConstraintTableAction{
+				Add:        add.From,
+				Constraint: constraint,
+			},
+		}, nil
+	}
+
+	if ok, toks, _ := p.parseKeywords("DROP", "CONSTRAINT"); ok {
+		constraintName, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		var caspos sqltoken.Pos
+		cascade, t, _ := p.parseKeyword("CASCADE")
+		if cascade {
+			caspos = t.To
+		}
+
+		return &sqlast.AlterTableStmt{
+			TableName: tableName,
+			Alter:     tok.From,
+			Action: &sqlast.DropConstraintTableAction{
+				Drop:       toks[0].From,
+				Name:       constraintName,
+				Cascade:    cascade,
+				CascadePos: caspos,
+			},
+		}, nil
+	}
+
+	if ok, toks, _ := p.parseKeywords("DROP", "COLUMN"); ok {
+		constraintName, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		var caspos sqltoken.Pos
+		cascade, t, _ := p.parseKeyword("CASCADE")
+		if cascade {
+			caspos = t.To
+		}
+
+		return &sqlast.AlterTableStmt{
+			TableName: tableName,
+			Alter:     tok.From,
+			Action: &sqlast.RemoveColumnTableAction{
+				Name:       constraintName,
+				Drop:       toks[0].From,
+				Cascade:    cascade,
+				CascadePos: caspos,
+			},
+		}, nil
+	}
+
+	if ok, toks, _ := p.parseKeywords("ALTER", "COLUMN"); ok {
+		action, err := p.parseAlterColumn(toks[0])
+		if err != nil {
+			return nil, errors.Errorf("parseAlterColumn failed: %w", err)
+		}
+
+		return &sqlast.AlterTableStmt{
+			Alter:     tok.From,
+			TableName: tableName,
+			Action:    action,
+		}, nil
 
 	}
 
 	t, _ := p.peekToken()
-	return nil, errors.Errorf("unknown alter operation %v", t)
+	return nil, p.expectedKeywords(t, "ADD", "DROP CONSTRAINT", "DROP COLUMN", "ALTER COLUMN")
 }
 
 func (p *Parser) parseDrop() (sqlast.Stmt, error) {
 	ok, tok, _ := p.parseKeyword("DROP")
 	if !ok {
-		return nil, errors.Errorf("expected DROP but %s", tok)
+		return nil, p.expectedKeywords(tok, "DROP")
 	}
 
 	ok, _, _ = p.parseKeyword("TABLE")
 
 	if !ok {
-		p.expectKeyword("INDEX")
+		if _, err := p.expectKeyword("INDEX"); err != nil {
+			if p.rawStmt {
+				return p.parseRawStmt(tok)
+			}
+			return nil, err
+		}
 		idents, err := p.parseColumnNames()
 		if err != nil {
 			return nil, errors.Errorf("parseColumnNames of DROP INDEX failed: %w", err)
@@ -1481,9 +2717,9 @@ func (p *Parser) parseAlterColumn(alt *sqltoken.Token) (*sqlast.AlterColumnTable
 		return nil, errors.Errorf("parseIdentifier failed: %w", err)
 	}
 
-	tok := p.mustNextToken()
-	if tok.Kind != sqltoken.SQLKeyword {
-		return nil, errors.Errorf("must be SQLKeyword but: %v", tok)
+	tok, _ := p.nextToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return nil, p.expected(tok, sqltoken.SQLKeyword)
 	}
 
 	word := tok.Value.(*sqltoken.SQLWord)
@@ -1515,7 +2751,8 @@ func (p *Parser) parseAlterColumn(alt *sqltoken.Token) (*sqlast.AlterColumnTable
 			}, nil
 		}
 
-		return nil, errors.Errorf("unknown SET action")
+		t, _ := p.peekToken()
+		return nil, p.expectedKeywords(t, "DEFAULT", "NOT NULL")
 	case "DROP":
 		if ok, deftok, _ := p.parseKeyword("DEFAULT"); ok {
 			return &sqlast.AlterColumnTableAction{
@@ -1537,7 +2774,8 @@ func (p *Parser) parseAlterColumn(alt *sqltoken.Token) (*sqlast.AlterColumnTable
 				},
 			}, nil
 		}
-		return nil, errors.Errorf("unknown DROP action")
+		t, _ := p.peekToken()
+		return nil, p.expectedKeywords(t, "DEFAULT", "NOT NULL")
 	case "TYPE":
 		tp, err := p.ParseDataType()
 		if err != nil {
@@ -1553,7 +2791,7 @@ func (p *Parser) parseAlterColumn(alt *sqltoken.Token) (*sqlast.AlterColumnTable
 			},
 		}, nil
 	default:
-		return nil, errors.Errorf("unknown alter column action %v", word)
+		return nil, p.expectedKeywords(tok, "SET", "DROP", "TYPE")
 	}
 }
 
@@ -1580,18 +2818,21 @@ func (p *Parser) parseDefaultExpr(precedence uint) (sqlast.Node, error) {
 		}
 		expr, err = p.parseInfix(expr, nextPrecedence)
 		if err != nil {
-			return nil, errors.Errorf("parseInfix failed: %w")
+			return nil, errors.Errorf("parseInfix failed: %w", err)
 		}
 	}
 	return expr, nil
 }
 
-func (p *Parser) parseOptionalAlias(reservedKeywords map[string]struct{}) *sqlast.Ident {
+func (p *Parser) parseOptionalAlias(reservedKeywords map[string]struct{}) (*sqlast.Ident, error) {
 	afterAs, _, _ := p.parseKeyword("AS")
 	maybeAlias, _ := p.nextToken()
 
 	if maybeAlias == nil {
-		return nil
+		if afterAs {
+			return nil, p.errorf(nil, "expected an identifier after AS but found end of input")
+		}
+		return nil, nil
 	}
 
 	if maybeAlias.Kind == sqltoken.SQLKeyword {
@@ -1602,35 +2843,26 @@ func (p *Parser) parseOptionalAlias(reservedKeywords map[string]struct{}) *sqlas
 				Value: word.String(),
 				From:  maybeAlias.From,
 				To:    maybeAlias.To,
-			}
+			}, nil
 		}
 	}
 	if afterAs {
-		log.Panicf("expected an identifier after AS")
+		return nil, p.errorf(maybeAlias, "expected an identifier after AS but found %s", tokenString(maybeAlias))
 	}
 	p.prevToken()
-	return nil
+	return nil, nil
 }
 
 func (p *Parser) parseCTEList() ([]*sqlast.CTE, error) {
 	var ctes []*sqlast.CTE
 
 	for {
-		alias, err := p.parseIdentifier()
-		if err != nil {
-			return nil, errors.Errorf("parseIdentifier failed: %w", err)
-		}
-		p.expectKeyword("AS")
-		p.expectToken(sqltoken.LParen)
-		q, err := p.parseQuery()
+		cte, err := p.parseCTE()
 		if err != nil {
-			return nil, errors.Errorf("parseQuery failed: %w", err)
+			return nil, errors.Errorf("parseCTE failed: %w", err)
 		}
-		ctes = append(ctes, &sqlast.CTE{
-			Alias: alias,
-			Query: q,
-		})
-		p.expectToken(sqltoken.RParen)
+		ctes = append(ctes, cte)
+
 		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
 			break
 		}
@@ -1638,80 +2870,260 @@ func (p *Parser) parseCTEList() ([]*sqlast.CTE, error) {
 	return ctes, nil
 }
 
-func (p *Parser) parseFromClause() ([]sqlast.TableReference, error) {
-	var res []sqlast.TableReference
-
-	table, err := p.parseTableReference()
+func (p *Parser) parseCTE() (*sqlast.CTE, error) {
+	alias, err := p.parseIdentifier()
 	if err != nil {
-		return nil, errors.Errorf("parseTable failed: %w", err)
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+	cte := &sqlast.CTE{
+		Alias: alias,
 	}
 
-	res = append(res, table)
-
-	for {
-		ok, _ := p.consumeToken(sqltoken.Comma)
-		if !ok {
-			break
-		}
-		table, err := p.parseTableReference()
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		cte.Columns, err = p.parseColumnNames()
 		if err != nil {
-			return nil, errors.Errorf("parseTable failed: %w", err)
+			return nil, errors.Errorf("parseColumnNames failed: %w", err)
+		}
+		if _, err := p.expectToken(sqltoken.RParen); err != nil {
+			return nil, err
 		}
-		res = append(res, table)
 	}
 
-	return res, nil
-}
-
-func (p *Parser) parseTableReference() (sqlast.TableReference, error) {
-	leftElem, err := p.parseTableFactor()
-	if err != nil {
-		return nil, errors.Errorf("parse joined table left element failed: %w", err)
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return nil, err
 	}
 
-	e := sqlast.TableReference(leftElem)
-
-	for {
-		right, err := p.parseTableReferenceRight()
-		if err != nil {
-			return nil, errors.Errorf("parse table reference right failed: %w", err)
+	tok, _ := p.peekToken()
+	notMaterialized, _, _ := p.parseKeywords("NOT", "MATERIALIZED")
+	materialized := notMaterialized
+	if !notMaterialized {
+		materialized, _, _ = p.parseKeyword("MATERIALIZED")
+	}
+	if materialized {
+		if !p.dialect.Supports(dialect.CTEMaterialized) {
+			return nil, p.unsupported(tok, "MATERIALIZED")
 		}
+		b := !notMaterialized
+		cte.Materialized = &b
+	}
 
-		if right == nil {
-			break
-		}
+	if _, err := p.expectToken(sqltoken.LParen); err != nil {
+		return nil, err
+	}
 
-		switch rtp := right.(type) {
-		case *sqlast.NaturalJoin:
-			rtp.LeftElement = &sqlast.TableJoinElement{
-				Ref: e,
+	if tok, _ := p.peekToken(); tok != nil && tok.Kind == sqltoken.SQLKeyword {
+		var dml func() (sqlast.Stmt, error)
+		switch tok.Value.(*sqltoken.SQLWord).Keyword {
+		case "INSERT":
+			dml = p.parseInsert
+		case "UPDATE":
+			dml = p.parseUpdate
+		case "DELETE":
+			dml = p.parseDelete
+		}
+		if dml != nil {
+			if !p.dialect.Supports(dialect.DataModifyingCTE) {
+				return nil, p.unsupported(tok, "data-modifying statement in WITH")
 			}
-			e = rtp
-		case *sqlast.CrossJoin:
-			rtp.Reference = leftElem
-			e = rtp
-		case *sqlast.QualifiedJoin:
-			rtp.LeftElement = &sqlast.TableJoinElement{
-				Ref: e,
+			cte.DML, err = dml()
+			if err != nil {
+				return nil, errors.Errorf("parse data-modifying statement failed: %w", err)
 			}
-			e = rtp
-		default:
-			return nil, errors.Errorf("unknown join")
 		}
 	}
-
-	return e, nil
-}
-
-func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
-	if ok, _ := p.consumeToken(sqltoken.Comma); ok {
-		p.prevToken()
-		return nil, nil
+	if cte.DML == nil {
+		cte.Query, err = p.parseQuery()
+		if err != nil {
+			return nil, errors.Errorf("parseQuery failed: %w", err)
+		}
 	}
 
-	tok, err := p.nextToken()
+	r, err := p.expectToken(sqltoken.RParen)
 	if err != nil {
-		return nil, nil
+		return nil, err
+	}
+	cte.RParen = r.To
+
+	if ok, tok, _ := p.parseKeyword("SEARCH"); ok {
+		if !p.dialect.Supports(dialect.CTESearchCycle) {
+			return nil, p.unsupported(tok, "SEARCH")
+		}
+		cte.Search, err = p.parseCTESearch(tok)
+		if err != nil {
+			return nil, errors.Errorf("parseCTESearch failed: %w", err)
+		}
+	}
+
+	if ok, tok, _ := p.parseKeyword("CYCLE"); ok {
+		if !p.dialect.Supports(dialect.CTESearchCycle) {
+			return nil, p.unsupported(tok, "CYCLE")
+		}
+		cte.Cycle, err = p.parseCTECycle(tok)
+		if err != nil {
+			return nil, errors.Errorf("parseCTECycle failed: %w", err)
+		}
+	}
+
+	return cte, nil
+}
+
+// parseCTESearch parses the rest of `SEARCH { BREADTH | DEPTH } FIRST BY columns SET column`.
+func (p *Parser) parseCTESearch(search *sqltoken.Token) (*sqlast.CTESearch, error) {
+	s := &sqlast.CTESearch{
+		Search: search.From,
+	}
+
+	if ok, _, _ := p.parseKeyword("BREADTH"); ok {
+		s.Breadth = true
+	} else if ok, tok, _ := p.parseKeyword("DEPTH"); !ok {
+		return nil, p.expectedKeywords(tok, "BREADTH", "DEPTH")
+	}
+	if _, err := p.expectKeyword("FIRST"); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKeyword("BY"); err != nil {
+		return nil, err
+	}
+
+	columns, err := p.parseColumnNames()
+	if err != nil {
+		return nil, errors.Errorf("parseColumnNames failed: %w", err)
+	}
+	s.Columns = columns
+
+	if _, err := p.expectKeyword("SET"); err != nil {
+		return nil, err
+	}
+	s.Set, err = p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	return s, nil
+}
+
+// parseCTECycle parses the rest of `CYCLE columns SET column [TO value DEFAULT value] USING column`.
+func (p *Parser) parseCTECycle(cycle *sqltoken.Token) (*sqlast.CTECycle, error) {
+	c := &sqlast.CTECycle{
+		Cycle: cycle.From,
+	}
+
+	columns, err := p.parseColumnNames()
+	if err != nil {
+		return nil, errors.Errorf("parseColumnNames failed: %w", err)
+	}
+	c.Columns = columns
+
+	if _, err := p.expectKeyword("SET"); err != nil {
+		return nil, err
+	}
+	c.Set, err = p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	if ok, _, _ := p.parseKeyword("TO"); ok {
+		c.To, err = p.parsePrefix()
+		if err != nil {
+			return nil, errors.Errorf("parsePrefix failed: %w", err)
+		}
+		if _, err := p.expectKeyword("DEFAULT"); err != nil {
+			return nil, err
+		}
+		c.Default, err = p.parsePrefix()
+		if err != nil {
+			return nil, errors.Errorf("parsePrefix failed: %w", err)
+		}
+	}
+
+	if _, err := p.expectKeyword("USING"); err != nil {
+		return nil, err
+	}
+	c.Using, err = p.parseIdentifier()
+	if err != nil {
+		return nil, errors.Errorf("parseIdentifier failed: %w", err)
+	}
+
+	return c, nil
+}
+
+func (p *Parser) parseFromClause() ([]sqlast.TableReference, error) {
+	var res []sqlast.TableReference
+
+	table, err := p.parseTableReference()
+	if err != nil {
+		return nil, errors.Errorf("parseTable failed: %w", err)
+	}
+
+	res = append(res, table)
+
+	for {
+		ok, _ := p.consumeToken(sqltoken.Comma)
+		if !ok {
+			break
+		}
+		table, err := p.parseTableReference()
+		if err != nil {
+			return nil, errors.Errorf("parseTable failed: %w", err)
+		}
+		res = append(res, table)
+	}
+
+	return res, nil
+}
+
+func (p *Parser) parseTableReference() (sqlast.TableReference, error) {
+	leftElem, err := p.parseTableFactor()
+	if err != nil {
+		return nil, errors.Errorf("parse joined table left element failed: %w", err)
+	}
+
+	e := sqlast.TableReference(leftElem)
+
+	for {
+		right, err := p.parseTableReferenceRight()
+		if err != nil {
+			return nil, errors.Errorf("parse table reference right failed: %w", err)
+		}
+
+		if right == nil {
+			break
+		}
+
+		switch rtp := right.(type) {
+		case *sqlast.NaturalJoin:
+			rtp.LeftElement = &sqlast.TableJoinElement{
+				Ref: e,
+			}
+			e = rtp
+		case *sqlast.CrossJoin:
+			rtp.Reference = e
+			e = rtp
+		case *sqlast.ApplyJoin:
+			rtp.Reference = e
+			e = rtp
+		case *sqlast.QualifiedJoin:
+			rtp.LeftElement = &sqlast.TableJoinElement{
+				Ref: e,
+			}
+			e = rtp
+		default:
+			return nil, errors.Errorf("unknown join")
+		}
+	}
+
+	return e, nil
+}
+
+func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
+	if ok, _ := p.consumeToken(sqltoken.Comma); ok {
+		p.prevToken()
+		return nil, nil
+	}
+
+	tok, err := p.nextToken()
+	if err != nil {
+		return nil, nil
 	}
 	word, ok := tok.Value.(*sqltoken.SQLWord)
 	if !ok {
@@ -1725,7 +3137,9 @@ func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
 		if err != nil {
 			return nil, errors.Errorf("parse natural join type failed: %w", err)
 		}
-		p.expectKeyword("JOIN")
+		if _, err := p.expectKeyword("JOIN"); err != nil {
+			return nil, err
+		}
 		rightElem, err := p.parseTableReference()
 		if err != nil {
 			return nil, errors.Errorf("parse natural join right element failed: %w", err)
@@ -1737,8 +3151,28 @@ func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
 				Ref: rightElem,
 			},
 		}, nil
-	case "CROSS":
-		p.expectKeyword("JOIN")
+	case "CROSS", "OUTER":
+		if ok, a, _ := p.parseKeyword("APPLY"); ok {
+			if !p.dialect.Supports(dialect.Apply) {
+				return nil, p.unsupported(a, word.Keyword+" APPLY")
+			}
+			rightElem, err := p.parseTableFactor()
+			if err != nil {
+				return nil, errors.Errorf("parse apply right element failed: %w", err)
+			}
+
+			return &sqlast.ApplyJoin{
+				Outer:  word.Keyword == "OUTER",
+				Factor: rightElem,
+			}, nil
+		}
+		if word.Keyword == "OUTER" {
+			p.prevToken()
+			return nil, nil
+		}
+		if _, err := p.expectKeyword("JOIN"); err != nil {
+			return nil, err
+		}
 		rightElem, err := p.parseTableFactor()
 		if err != nil {
 			return nil, errors.Errorf("parse cross join right element failed: %w", err)
@@ -1748,7 +3182,9 @@ func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
 			Factor: rightElem,
 		}, nil
 	case "INNER":
-		p.expectKeyword("JOIN")
+		if _, err := p.expectKeyword("JOIN"); err != nil {
+			return nil, err
+		}
 		ref, err := p.parseTableReference()
 		if err != nil {
 			return nil, errors.Errorf("parse inner join right elem filed: %w", err)
@@ -1771,7 +3207,9 @@ func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
 		if err != nil {
 			return nil, errors.Errorf("parse qualified join type failed: %w", err)
 		}
-		p.expectKeyword("JOIN")
+		if _, err := p.expectKeyword("JOIN"); err != nil {
+			return nil, err
+		}
 		ref, err := p.parseTableReference()
 		if err != nil {
 			return nil, errors.Errorf("parse qualified join right elem failed: %w", err)
@@ -1798,10 +3236,10 @@ func (p *Parser) parseTableReferenceRight() (sqlast.TableReference, error) {
 
 func (p *Parser) parseJoinType() (*sqlast.JoinType, error) {
 	tok, _ := p.nextToken()
-	word, ok := tok.Value.(*sqltoken.SQLWord)
-	if !ok {
-		return nil, errors.Errorf("unknown join type %v", tok)
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return nil, p.expected(tok, sqltoken.SQLKeyword)
 	}
+	word := tok.Value.(*sqltoken.SQLWord)
 
 	switch word.Keyword {
 	case "INNER":
@@ -1840,7 +3278,7 @@ func (p *Parser) parseJoinType() (*sqlast.JoinType, error) {
 		p.prevToken()
 		return &sqlast.JoinType{Condition: sqlast.IMPLICIT}, nil
 	default:
-		return nil, errors.Errorf("unknown join type: %v", word)
+		return nil, p.expectedKeywords(tok, "INNER", "LEFT", "RIGHT", "FULL", "JOIN")
 	}
 }
 
@@ -1858,16 +3296,20 @@ func (p *Parser) parseJoinSpec() (sqlast.JoinSpec, error) {
 
 	ok, _, _ := p.parseKeyword("USING")
 	if !ok {
-		tok, _ := p.nextToken()
-		return nil, errors.Errorf("unknown join spec need USING or ON but: %v", tok)
+		tok, _ := p.peekToken()
+		return nil, p.expectedKeywords(tok, "ON", "USING")
 	}
 
-	p.expectToken(sqltoken.LParen)
+	if _, err := p.expectToken(sqltoken.LParen); err != nil {
+		return nil, err
+	}
 	idents, err := p.parseListOfIds(sqltoken.Comma)
 	if err != nil {
 		return nil, errors.Errorf("parse named columns join list failed: %w", err)
 	}
-	p.expectToken(sqltoken.RParen)
+	if _, err := p.expectToken(sqltoken.RParen); err != nil {
+		return nil, err
+	}
 
 	return &sqlast.NamedColumnsJoin{
 		ColumnList: idents,
@@ -1875,102 +3317,634 @@ func (p *Parser) parseJoinSpec() (sqlast.JoinSpec, error) {
 }
 
 func (p *Parser) parseTableFactor() (sqlast.TableFactor, error) {
-	isLateral, _, _ := p.parseKeyword("LATERAL")
+	isLateral, lateral, _ := p.parseKeyword("LATERAL")
 	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		l := p.tokens[p.index-1]
 		subquery, err := p.parseQuery()
 		if err != nil {
 			return nil, errors.Errorf("parseQuery failed: %w", err)
 		}
-		p.expectToken(sqltoken.RParen)
-		alias := p.parseOptionalAlias(dialect.ReservedForTableAlias)
-		return &sqlast.Derived{
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		alias, err := p.parseOptionalAlias(p.dialect.ReservedForTableAlias())
+		if err != nil {
+			return nil, errors.Errorf("parseOptionalAlias failed: %w", err)
+		}
+		d := &sqlast.Derived{
 			Lateral:  isLateral,
+			LParen:   l.From,
+			RParen:   r.To,
 			SubQuery: subquery,
 			Alias:    alias,
-		}, nil
-	} else if isLateral && !ok {
-		t, _ := p.nextToken()
-		return nil, errors.Errorf("after lateral expected %s but %+v", sqltoken.LParen, t)
+		}
+		if isLateral {
+			d.LateralPos = lateral.From
+		}
+		if alias != nil {
+			d.Columns, d.ColumnsRParen, err = p.parseColumnAliases()
+			if err != nil {
+				return nil, errors.Errorf("parseColumnAliases failed: %w", err)
+			}
+		}
+		return d, nil
+	}
+
+	tf := &sqlast.TableFunction{
+		Lateral: isLateral,
+	}
+	if isLateral {
+		tf.LateralPos = lateral.From
+	}
+
+	if ok, toks, _ := p.parseKeywords("ROWS", "FROM"); ok {
+		if !p.dialect.Supports(dialect.TableFunctions) {
+			return nil, p.unsupported(toks[0], "ROWS FROM")
+		}
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
+		for {
+			name, err := p.parseObjectName()
+			if err != nil {
+				return nil, errors.Errorf("parseObjectName failed: %w", err)
+			}
+			if _, err := p.expectToken(sqltoken.LParen); err != nil {
+				return nil, err
+			}
+			f, err := p.parseTableFunctionCall(name)
+			if err != nil {
+				return nil, errors.Errorf("parseTableFunctionCall failed: %w", err)
+			}
+			tf.Functions = append(tf.Functions, f)
+			if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+				break
+			}
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		tf.RowsFrom = true
+		tf.RowsFromPos = toks[0].From
+		tf.RowsFromRParen = r.To
+
+		if err := p.parseTableFunctionAlias(tf); err != nil {
+			return nil, errors.Errorf("parseTableFunctionAlias failed: %w", err)
+		}
+		return tf, nil
 	}
 
 	name, err := p.parseObjectName()
 	if err != nil {
 		return nil, errors.Errorf("parseObjectName failed: %w", err)
 	}
-	var args []sqlast.Node
 	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
-		a, err := p.parseOptionalArgs()
+		f, err := p.parseTableFunctionCall(name)
+		if err != nil {
+			return nil, errors.Errorf("parseTableFunctionCall failed: %w", err)
+		}
+		tf.Functions = []*sqlast.Function{f}
+
+		if err := p.parseTableFunctionAlias(tf); err != nil {
+			return nil, errors.Errorf("parseTableFunctionAlias failed: %w", err)
+		}
+		return tf, nil
+	} else if isLateral {
+		t, _ := p.peekToken()
+		return nil, p.expected(t, sqltoken.LParen)
+	}
+
+	var systemTime *sqlast.SystemTime
+	if ok, toks, _ := p.parseKeywords("FOR", "SYSTEM_TIME"); ok {
+		if !p.dialect.Supports(dialect.SystemTime) {
+			return nil, p.unsupported(toks[0], "FOR SYSTEM_TIME")
+		}
+		systemTime, err = p.parseSystemTime(toks[0])
+		if err != nil {
+			return nil, errors.Errorf("parseSystemTime failed: %w", err)
+		}
+	}
+
+	alias, err := p.parseOptionalAlias(p.dialect.ReservedForTableAlias())
+	if err != nil {
+		return nil, errors.Errorf("parseOptionalAlias failed: %w", err)
+	}
+	var columns []*sqlast.Ident
+	var columnsRParen sqltoken.Pos
+	if alias != nil {
+		columns, columnsRParen, err = p.parseColumnAliases()
+		if err != nil {
+			return nil, errors.Errorf("parseColumnAliases failed: %w", err)
+		}
+	}
+
+	var sample *sqlast.TableSample
+	if ok, tok, _ := p.parseKeyword("TABLESAMPLE"); ok {
+		if !p.dialect.Supports(dialect.TableSample) {
+			return nil, p.unsupported(tok, "TABLESAMPLE")
+		}
+		sample, err = p.parseTableSample(tok)
 		if err != nil {
-			return nil, errors.Errorf("parseOptionalArgs failed: %w", err)
+			return nil, errors.Errorf("parseTableSample failed: %w", err)
 		}
-		args = a
 	}
-	alias := p.parseOptionalAlias(dialect.ReservedForTableAlias)
 
 	var withHints []sqlast.Node
-	if ok, _, _ := p.parseKeyword("WITH"); ok {
+	var withHintsRParen sqltoken.Pos
+	if !p.dialect.Supports(dialect.TableHints) {
+		// WITH is not a table hint
+	} else if ok, _, _ := p.parseKeyword("WITH"); ok {
 		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
 			h, err := p.parseExprList()
 			if err != nil {
 				return nil, errors.Errorf("parseExprList failed: %w", err)
 			}
 			withHints = h
-			p.expectToken(sqltoken.RParen)
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
+			}
+			withHintsRParen = r.To
 		} else {
 			p.prevToken()
 		}
 	}
 
 	return &sqlast.Table{
-		Name:      name,
-		Args:      args,
-		Alias:     alias,
-		WithHints: withHints,
+		Name:            name,
+		SystemTime:      systemTime,
+		Alias:           alias,
+		Columns:         columns,
+		ColumnsRParen:   columnsRParen,
+		Sample:          sample,
+		WithHints:       withHints,
+		WithHintsRParen: withHintsRParen,
 	}, nil
 
 }
 
-func (p *Parser) parseLimit() (*sqlast.LimitExpr, error) {
-	if ok, _, _ := p.parseKeyword("ALL"); ok {
-		return &sqlast.LimitExpr{All: true}, nil
+// parseSystemTime parses the rest of
+// FOR SYSTEM_TIME { AS OF v | FROM v TO v | BETWEEN v AND v | CONTAINED IN (v, v) | ALL }
+// after the FOR keyword tok.
+func (p *Parser) parseSystemTime(tok *sqltoken.Token) (*sqlast.SystemTime, error) {
+	s := &sqlast.SystemTime{
+		From: tok.From,
 	}
 
-	i, tok, err := p.parseLiteralInt()
-	if err != nil {
-		return nil, errors.Errorf("invalid limit value: %w", err)
+	if ok, _, _ := p.parseKeywords("AS", "OF"); ok {
+		v, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		s.Type = sqlast.SystemTimeAsOf
+		s.Value = v
+		s.To = v.End()
+		return s, nil
 	}
 
-	var offset *sqlast.LongValue
-	if ok, tok, _ := p.parseKeyword("OFFSET"); ok {
-		o, _, err := p.parseLiteralInt()
+	if ok, _, _ := p.parseKeyword("FROM"); ok {
+		start, err := p.ParseExpr()
 		if err != nil {
-			return nil, errors.Errorf("invalid offset value: %w", err)
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
-		offset = &sqlast.LongValue{
-			Long: int64(o),
-			From: tok.From,
-			To:   tok.To,
+		if _, err := p.expectKeyword("TO"); err != nil {
+			return nil, err
+		}
+		stop, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
+		s.Type = sqlast.SystemTimeFromTo
+		s.Start, s.Stop = start, stop
+		s.To = stop.End()
+		return s, nil
 	}
 
-	return &sqlast.LimitExpr{
-		LimitValue: &sqlast.LongValue{
-			Long: int64(i),
-			From: tok.From,
-			To:   tok.To,
-		},
-		OffsetValue: offset,
-	}, nil
-}
-
-func (p *Parser) parseIdentifier() (*sqlast.Ident, error) {
-	tok, err := p.nextToken()
-	if err != nil {
-		return nil, errors.Errorf("nextToken failed: %w", err)
+	if ok, _, _ := p.parseKeyword("BETWEEN"); ok {
+		start, err := p.parsePrefix()
+		if err != nil {
+			return nil, errors.Errorf("parsePrefix failed: %w", err)
+		}
+		if _, err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		stop, err := p.parsePrefix()
+		if err != nil {
+			return nil, errors.Errorf("parsePrefix failed: %w", err)
+		}
+		s.Type = sqlast.SystemTimeBetween
+		s.Start, s.Stop = start, stop
+		s.To = stop.End()
+		return s, nil
 	}
-	word, ok := tok.Value.(*sqltoken.SQLWord)
-	if !ok {
-		return nil, errors.Errorf("expected identifier but %+v", tok)
+
+	if ok, _, _ := p.parseKeywords("CONTAINED", "IN"); ok {
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
+		start, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		if _, err := p.expectToken(sqltoken.Comma); err != nil {
+			return nil, err
+		}
+		stop, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		s.Type = sqlast.SystemTimeContainedIn
+		s.Start, s.Stop = start, stop
+		s.To = r.To
+		return s, nil
+	}
+
+	if ok, all, _ := p.parseKeyword("ALL"); ok {
+		s.Type = sqlast.SystemTimeAll
+		s.To = all.To
+		return s, nil
+	}
+
+	t, _ := p.peekToken()
+	return nil, p.expectedKeywords(t, "AS", "FROM", "BETWEEN", "CONTAINED", "ALL")
+}
+
+// parseTableSample parses the rest of `TABLESAMPLE [method] (args [PERCENT | ROWS]) [REPEATABLE (seed)]`
+// after the TABLESAMPLE keyword tok.
+func (p *Parser) parseTableSample(tok *sqltoken.Token) (*sqlast.TableSample, error) {
+	s := &sqlast.TableSample{
+		From: tok.From,
+	}
+
+	if t, _ := p.peekToken(); t != nil && t.Kind != sqltoken.LParen {
+		method, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		s.Method = method
+	}
+
+	if _, err := p.expectToken(sqltoken.LParen); err != nil {
+		return nil, err
+	}
+	args, err := p.parseExprList()
+	if err != nil {
+		return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+	s.Args = args
+	if ok, _, _ := p.parseKeyword("PERCENT"); ok {
+		s.Unit = sqlast.SamplePercent
+	} else if ok, _, _ := p.parseKeyword("ROWS"); ok {
+		s.Unit = sqlast.SampleRows
+	}
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+	s.To = r.To
+
+	if ok, _, _ := p.parseKeyword("REPEATABLE"); ok {
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
+		seed, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		s.Seed = seed
+		s.To = r.To
+	}
+
+	return s, nil
+}
+
+// parseTableFunctionCall parses the arguments of a function in FROM after its LParen.
+func (p *Parser) parseTableFunctionCall(name *sqlast.ObjectName) (*sqlast.Function, error) {
+	args, err := p.parseOptionalArgs()
+	if err != nil {
+		return nil, errors.Errorf("parseOptionalArgs failed: %w", err)
+	}
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlast.Function{
+		Name:       name,
+		Args:       args,
+		ArgsRParen: r.To,
+	}, nil
+}
+
+// parseTableFunctionAlias parses
+// [WITH ORDINALITY] [[AS] alias [(column [data_type], ...)] | AS (column data_type, ...)]
+// after the function of tf.
+func (p *Parser) parseTableFunctionAlias(tf *sqlast.TableFunction) error {
+	if ok, toks, _ := p.parseKeywords("WITH", "ORDINALITY"); ok {
+		if !p.dialect.Supports(dialect.TableFunctions) {
+			return p.unsupported(toks[0], "WITH ORDINALITY")
+		}
+		tf.WithOrdinality = true
+		tf.OrdinalityPos = toks[1].To
+	}
+
+	// column definition list without alias
+	if ok, _, _ := p.parseKeyword("AS"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+			if err := p.parseTableFunctionColumns(tf); err != nil {
+				return err
+			}
+			if len(tf.Columns) != 0 {
+				return p.errorf(p.tokens[p.index-1], "column definition list requires data types")
+			}
+			return nil
+		}
+		p.prevToken()
+	}
+
+	alias, err := p.parseOptionalAlias(p.dialect.ReservedForTableAlias())
+	if err != nil {
+		return errors.Errorf("parseOptionalAlias failed: %w", err)
+	}
+	tf.Alias = alias
+
+	if alias != nil {
+		if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+			return p.parseTableFunctionColumns(tf)
+		}
+	}
+
+	return nil
+}
+
+// parseTableFunctionColumns parses column aliases or a column definition list after its LParen.
+func (p *Parser) parseTableFunctionColumns(tf *sqlast.TableFunction) error {
+	for {
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return errors.Errorf("parseIdentifier failed: %w", err)
+		}
+
+		if tok, _ := p.peekToken(); tok != nil && tok.Kind != sqltoken.Comma && tok.Kind != sqltoken.RParen {
+			if !p.dialect.Supports(dialect.TableFunctions) {
+				return p.unsupported(tok, "column definition list")
+			}
+			tp, err := p.ParseDataType()
+			if err != nil {
+				return errors.Errorf("ParseDataType failed: %w", err)
+			}
+			tf.ColumnDefs = append(tf.ColumnDefs, &sqlast.TableFunctionColumn{
+				Name:     name,
+				DataType: tp,
+			})
+		} else {
+			tf.Columns = append(tf.Columns, name)
+		}
+
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return err
+	}
+	if len(tf.Columns) != 0 && len(tf.ColumnDefs) != 0 {
+		return p.errorf(r, "column aliases and column definitions cannot be mixed")
+	}
+	tf.ColumnsRParen = r.To
+
+	return nil
+}
+
+// parseColumnAliases parses the optional column aliases `(a, b)` after a table alias.
+func (p *Parser) parseColumnAliases() ([]*sqlast.Ident, sqltoken.Pos, error) {
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		return nil, sqltoken.Pos{}, nil
+	}
+	columns, err := p.parseColumnNames()
+	if err != nil {
+		return nil, sqltoken.Pos{}, errors.Errorf("parseColumnNames failed: %w", err)
+	}
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, sqltoken.Pos{}, err
+	}
+	return columns, r.To, nil
+}
+
+func (p *Parser) parseLimit(limit *sqltoken.Token) (*sqlast.LimitExpr, error) {
+	var l sqlast.Node
+	var allPos sqltoken.Pos
+	if ok, all, _ := p.parseKeyword("ALL"); ok {
+		allPos = all.To
+	} else {
+		v, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("invalid limit value: %w", err)
+		}
+		l = v
+	}
+
+	if t, _ := p.peekToken(); l != nil && t != nil && t.Kind == sqltoken.Comma {
+		if !p.dialect.Supports(dialect.LimitComma) {
+			return nil, p.unsupported(t, "LIMIT offset, count")
+		}
+		p.mustNextToken()
+		count, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("invalid limit value: %w", err)
+		}
+		return &sqlast.LimitExpr{
+			Limit:       limit.From,
+			LimitValue:  count,
+			OffsetValue: l,
+			Syntax:      sqlast.LimitCommaSyntax,
+		}, nil
+	}
+
+	var offset sqlast.Node
+	if ok, _, _ := p.parseKeyword("OFFSET"); ok {
+		o, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("invalid offset value: %w", err)
+		}
+		offset = o
+	}
+
+	return &sqlast.LimitExpr{
+		All:         l == nil,
+		AllPos:      allPos,
+		Limit:       limit.From,
+		LimitValue:  l,
+		OffsetValue: offset,
+	}, nil
+}
+
+// parseOffsetFetch parses the rest of
+// [OFFSET n [ROW | ROWS]] [FETCH { FIRST | NEXT } [n] { ROW | ROWS } { ONLY | WITH TIES }]
+// after the OFFSET or FETCH keyword tok.
+func (p *Parser) parseOffsetFetch(tok *sqltoken.Token) (*sqlast.LimitExpr, error) {
+	if !p.dialect.Supports(dialect.OffsetFetch) {
+		return nil, p.unsupported(tok, "OFFSET ... FETCH")
+	}
+
+	l := &sqlast.LimitExpr{
+		Limit:  tok.From,
+		Syntax: sqlast.OffsetFetchSyntax,
+	}
+
+	if tok.Value.(*sqltoken.SQLWord).Keyword == "OFFSET" {
+		offset, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("invalid offset value: %w", err)
+		}
+		l.OffsetValue = offset
+		l.To = offset.End()
+
+		if rows, rtok := p.parseRowOrRows(); rtok != nil {
+			l.OffsetRows = rows
+			l.To = rtok.To
+		}
+
+		if ok, _, _ := p.parseKeyword("LIMIT"); ok {
+			return p.parseLimitAfterOffset(l)
+		}
+		if ok, _, _ := p.parseKeyword("FETCH"); !ok {
+			return l, nil
+		}
+	}
+
+	if ok, _, _ := p.parseKeyword("FIRST"); ok {
+		l.FetchFirst = "FIRST"
+	} else if ok, _, _ := p.parseKeyword("NEXT"); ok {
+		l.FetchFirst = "NEXT"
+	} else {
+		tok, _ := p.peekToken()
+		return nil, p.expectedKeywords(tok, "FIRST", "NEXT")
+	}
+
+	rows, rtok := p.parseRowOrRows()
+	if rtok == nil {
+		count, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("invalid fetch value: %w", err)
+		}
+		l.LimitValue = count
+
+		rows, rtok = p.parseRowOrRows()
+		if rtok == nil {
+			tok, _ := p.peekToken()
+			return nil, p.expectedKeywords(tok, "ROW", "ROWS")
+		}
+	}
+	l.FetchRows = rows
+
+	if ok, tok, _ := p.parseKeyword("ONLY"); ok {
+		l.To = tok.To
+	} else if ok, toks, _ := p.parseKeywords("WITH", "TIES"); ok {
+		l.WithTies = true
+		l.To = toks[1].To
+	} else {
+		tok, _ := p.peekToken()
+		return nil, p.expectedKeywords(tok, "ONLY", "WITH TIES")
+	}
+
+	return l, nil
+}
+
+// parseLimitAfterOffset parses the rest of `OFFSET n LIMIT { ALL | count }` of PostgreSQL
+// after the LIMIT keyword.
+func (p *Parser) parseLimitAfterOffset(l *sqlast.LimitExpr) (*sqlast.LimitExpr, error) {
+	l.Syntax = sqlast.OffsetLimitSyntax
+	if ok, all, _ := p.parseKeyword("ALL"); ok {
+		l.All = true
+		l.AllPos = all.To
+		l.To = all.To
+		return l, nil
+	}
+
+	count, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("invalid limit value: %w", err)
+	}
+	l.LimitValue = count
+	l.To = count.End()
+	return l, nil
+}
+
+// parseRowOrRows consumes ROW or ROWS and returns the keyword with its token.
+func (p *Parser) parseRowOrRows() (string, *sqltoken.Token) {
+	for _, kw := range []string{"ROW", "ROWS"} {
+		if ok, tok, _ := p.parseKeyword(kw); ok {
+			return kw, tok
+		}
+	}
+	return "", nil
+}
+
+// newPlaceholder returns nil if tok is not a placeholder.
+// `@name` is tokenized as a word because '@' is an identifier start of some dialects.
+func newPlaceholder(tok *sqltoken.Token) *sqlast.Placeholder {
+	ph := &sqlast.Placeholder{
+		From: tok.From,
+		To:   tok.To,
+	}
+
+	switch v := tok.Value.(type) {
+	case string:
+		if tok.Kind != sqltoken.Placeholder {
+			return nil
+		}
+		switch v[0] {
+		case '$':
+			if i, err := strconv.Atoi(v[1:]); err == nil {
+				ph.Style = sqlast.DollarPlaceholder
+				ph.Index = i
+			} else {
+				ph.Style = sqlast.DollarNamePlaceholder
+				ph.Name = v[1:]
+			}
+		case ':':
+			ph.Style = sqlast.ColonPlaceholder
+			ph.Name = v[1:]
+		default:
+			ph.Style = sqlast.QuestionPlaceholder
+			ph.Index, _ = strconv.Atoi(v[1:])
+		}
+	case *sqltoken.SQLWord:
+		// @@name is a system variable of MySQL and SQL Server
+		if v.QuoteStyle != 0 || len(v.Value) < 2 || v.Value[0] != '@' || v.Value[1] == '@' {
+			return nil
+		}
+		ph.Style = sqlast.AtPlaceholder
+		ph.Name = v.Value[1:]
+	default:
+		return nil
+	}
+
+	return ph
+}
+
+func (p *Parser) parseIdentifier() (*sqlast.Ident, error) {
+	tok, err := p.nextToken()
+	if err != nil {
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+	word, ok := tok.Value.(*sqltoken.SQLWord)
+	if !ok {
+		return nil, p.errorf(tok, "expected an identifier but found %s", tokenString(tok))
 	}
 
 	return &sqlast.Ident{
@@ -2004,6 +3978,11 @@ func (p *Parser) parseColumnNames() ([]*sqlast.Ident, error) {
 }
 
 func (p *Parser) parseSubexpr(precedence uint) (sqlast.Node, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
 	expr, err := p.parsePrefix()
 	if err != nil {
 		return nil, errors.Errorf("parsePrefix failed: %w", err)
@@ -2027,12 +4006,51 @@ func (p *Parser) parseSubexpr(precedence uint) (sqlast.Node, error) {
 	return expr, nil
 }
 
+// operatorFeatures maps the operators which only some dialects accept to their feature.
+var operatorFeatures = map[sqlast.OperatorType]dialect.Feature{
+	sqlast.PGBitwiseXor:   dialect.HashXor,
+	sqlast.NullSafeEq:     dialect.NullSafeEqual,
+	sqlast.RegexMatch:     dialect.RegexOperators,
+	sqlast.RegexIMatch:    dialect.RegexOperators,
+	sqlast.RegexNotMatch:  dialect.RegexOperators,
+	sqlast.RegexNotIMatch: dialect.RegexOperators,
+	sqlast.Arrow:          dialect.JSONOperators,
+	sqlast.LongArrow:      dialect.JSONOperators,
+	sqlast.HashArrow:      dialect.JSONBOperators,
+	sqlast.HashLongArrow:  dialect.JSONBOperators,
+	sqlast.AtArrow:        dialect.JSONBOperators,
+	sqlast.ArrowAt:        dialect.JSONBOperators,
+	sqlast.Question:       dialect.JSONBOperators,
+	sqlast.QuestionPipe:   dialect.JSONBOperators,
+	sqlast.QuestionAnd:    dialect.JSONBOperators,
+	sqlast.Overlap:        dialect.JSONBOperators,
+	sqlast.ILike:          dialect.ILike,
+	sqlast.NotILike:       dialect.ILike,
+	sqlast.SimilarTo:      dialect.SimilarTo,
+	sqlast.NotSimilarTo:   dialect.SimilarTo,
+	sqlast.Regexp:         dialect.RegexpLike,
+	sqlast.NotRegexp:      dialect.RegexpLike,
+	sqlast.RLike:          dialect.RegexpLike,
+	sqlast.NotRLike:       dialect.RegexpLike,
+}
+
+// escapable is the set of pattern matches which take an ESCAPE clause.
+var escapable = map[sqlast.OperatorType]bool{
+	sqlast.Like:         true,
+	sqlast.NotLike:      true,
+	sqlast.ILike:        true,
+	sqlast.NotILike:     true,
+	sqlast.SimilarTo:    true,
+	sqlast.NotSimilarTo: true,
+}
+
 func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, error) {
 	operator := sqlast.None
 	tok, err := p.nextToken()
 	if err != nil {
 		return nil, errors.Errorf("nextToken failed: %w", err)
 	}
+	to := tok.To // last position of the operator
 
 	switch tok.Kind {
 	case sqltoken.Eq:
@@ -2057,6 +4075,67 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 		operator = sqlast.Modulus
 	case sqltoken.Div:
 		operator = sqlast.Divide
+	case sqltoken.DoublePipe:
+		switch {
+		case p.dialect.Supports(dialect.ConcatOperator):
+			operator = sqlast.StringConcat
+		case p.dialect.Supports(dialect.PipesAsOr):
+			operator = sqlast.Or
+		default:
+			return nil, p.unsupported(tok, "|| operator")
+		}
+	case sqltoken.Pipe:
+		operator = sqlast.BitwiseOr
+	case sqltoken.Ampersand:
+		operator = sqlast.BitwiseAnd
+	case sqltoken.Caret:
+		switch {
+		case p.dialect.Supports(dialect.CaretXor):
+			operator = sqlast.BitwiseXor
+		case p.dialect.Supports(dialect.CaretExponent):
+			operator = sqlast.Exponent
+		default:
+			return nil, p.unsupported(tok, "^ operator")
+		}
+	case sqltoken.Sharp:
+		operator = sqlast.PGBitwiseXor
+	case sqltoken.ShiftLeft:
+		operator = sqlast.ShiftLeft
+	case sqltoken.ShiftRight:
+		operator = sqlast.ShiftRight
+	case sqltoken.Spaceship:
+		operator = sqlast.NullSafeEq
+	case sqltoken.Tilde:
+		operator = sqlast.RegexMatch
+	case sqltoken.TildeAsterisk:
+		operator = sqlast.RegexIMatch
+	case sqltoken.ExclamationTilde:
+		operator = sqlast.RegexNotMatch
+	case sqltoken.ExclamationTildeAsterisk:
+		operator = sqlast.RegexNotIMatch
+	case sqltoken.Arrow:
+		operator = sqlast.Arrow
+	case sqltoken.LongArrow:
+		operator = sqlast.LongArrow
+	case sqltoken.HashArrow:
+		operator = sqlast.HashArrow
+	case sqltoken.HashLongArrow:
+		operator = sqlast.HashLongArrow
+	case sqltoken.AtArrow:
+		operator = sqlast.AtArrow
+	case sqltoken.ArrowAt:
+		operator = sqlast.ArrowAt
+	case sqltoken.Placeholder:
+		// `?` after an expression is the jsonb key exists operator
+		if tok.Value == "?" {
+			operator = sqlast.Question
+		}
+	case sqltoken.QuestionPipe:
+		operator = sqlast.QuestionPipe
+	case sqltoken.QuestionAmpersand:
+		operator = sqlast.QuestionAnd
+	case sqltoken.DoubleAmpersand:
+		operator = sqlast.Overlap
 	case sqltoken.SQLKeyword:
 		word := tok.Value.(*sqltoken.SQLWord)
 		switch word.Keyword {
@@ -2066,24 +4145,67 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 			operator = sqlast.Or
 		case "LIKE":
 			operator = sqlast.Like
+		case "ILIKE":
+			operator = sqlast.ILike
+		case "REGEXP":
+			operator = sqlast.Regexp
+		case "RLIKE":
+			operator = sqlast.RLike
+		case "SIMILAR":
+			if ok, t, _ := p.parseKeyword("TO"); ok {
+				operator = sqlast.SimilarTo
+				to = t.To
+			}
 		case "NOT":
-			ok, _, _ := p.parseKeyword("LIKE")
-			if ok {
+			if ok, t, _ := p.parseKeyword("LIKE"); ok {
 				operator = sqlast.NotLike
+				to = t.To
+			} else if ok, t, _ := p.parseKeyword("ILIKE"); ok {
+				operator = sqlast.NotILike
+				to = t.To
+			} else if ok, toks, _ := p.parseKeywords("SIMILAR", "TO"); ok {
+				operator = sqlast.NotSimilarTo
+				to = toks[1].To
+			} else if ok, t, _ := p.parseKeyword("REGEXP"); ok {
+				operator = sqlast.NotRegexp
+				to = t.To
+			} else if ok, t, _ := p.parseKeyword("RLIKE"); ok {
+				operator = sqlast.NotRLike
+				to = t.To
 			}
 		}
 	}
 
 	if operator != sqlast.None {
+		op := &sqlast.Operator{Type: operator, From: tok.From, To: to}
+		if f, ok := operatorFeatures[operator]; ok && !p.dialect.Supports(f) {
+			return nil, p.unsupported(tok, fmt.Sprintf("%s operator", op.ToSQLString()))
+		}
+
 		right, err := p.parseSubexpr(precedence)
 		if err != nil {
 			return nil, errors.Errorf("parseSubexpr failed: %w", err)
 		}
 
-		return &sqlast.BinaryExpr{
+		match := &sqlast.BinaryExpr{
 			Left:  expr,
-			Op:    &sqlast.Operator{Type: operator, From: tok.From, To: tok.To},
+			Op:    op,
 			Right: right,
+		}
+
+		if !escapable[operator] {
+			return match, nil
+		}
+		if ok, _, _ := p.parseKeyword("ESCAPE"); !ok {
+			return match, nil
+		}
+		escape, err := p.parseSubexpr(precedence)
+		if err != nil {
+			return nil, errors.Errorf("parseSubexpr failed: %w", err)
+		}
+		return &sqlast.PatternEscape{
+			Match:  match,
+			Escape: escape,
 		}, nil
 	}
 
@@ -2102,7 +4224,7 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 					X: expr,
 				}, nil
 			}
-			return nil, errors.Errorf("NULL or NOT NULL after IS")
+			return p.parseIs(expr, precedence)
 		case "NOT", "IN", "BETWEEN":
 			p.prevToken()
 			negated, _, _ := p.parseKeyword("NOT")
@@ -2116,11 +4238,47 @@ func (p *Parser) parseInfix(expr sqlast.Node, precedence uint) (sqlast.Node, err
 	}
 
 	if tok.Kind == sqltoken.DoubleColon {
+		if !p.dialect.Supports(dialect.DoubleColonCast) {
+			return nil, p.unsupported(tok, "::")
+		}
 		return p.parsePGCast(expr)
 	}
 
-	log.Panicf("no infix parser for sqltoken %+v", tok)
-	return nil, nil
+	return nil, p.errorf(tok, "unexpected %s in expression", tokenString(tok))
+}
+
+// parseIs parses the rest of `X IS [NOT] ...` other than NULL tests.
+func (p *Parser) parseIs(expr sqlast.Node, precedence uint) (sqlast.Node, error) {
+	negated, _, _ := p.parseKeyword("NOT")
+
+	if ok, _, _ := p.parseKeywords("DISTINCT", "FROM"); ok {
+		y, err := p.parseSubexpr(precedence)
+		if err != nil {
+			return nil, errors.Errorf("parseSubexpr failed: %w", err)
+		}
+		return &sqlast.IsDistinctFrom{
+			X:       expr,
+			Y:       y,
+			Negated: negated,
+		}, nil
+	}
+
+	for _, v := range []string{"TRUE", "FALSE", "UNKNOWN"} {
+		if ok, t, _ := p.parseKeyword(v); ok {
+			return &sqlast.IsBool{
+				X:       expr,
+				Negated: negated,
+				Value:   v,
+				To:      t.To,
+			}, nil
+		}
+	}
+
+	t, _ := p.peekToken()
+	if negated {
+		return nil, p.expectedKeywords(t, "NULL", "DISTINCT FROM", "TRUE", "FALSE", "UNKNOWN")
+	}
+	return nil, p.expectedKeywords(t, "NULL", "NOT", "DISTINCT FROM", "TRUE", "FALSE", "UNKNOWN")
 }
 
 // TODO position
@@ -2136,19 +4294,22 @@ func (p *Parser) parsePGCast(expr sqlast.Node) (sqlast.Node, error) {
 }
 
 func (p *Parser) parseIn(expr sqlast.Node, negated bool) (sqlast.Node, error) {
-	p.expectToken(sqltoken.LParen)
+	if _, err := p.expectToken(sqltoken.LParen); err != nil {
+		return nil, err
+	}
 	sok, _, _ := p.parseKeyword("SELECT")
 	wok, _, _ := p.parseKeyword("WITH")
+	vok, _, _ := p.parseKeyword("VALUES")
 	var inop sqlast.Node
-	if sok || wok {
+	if sok || wok || vok {
 		p.prevToken()
 		q, err := p.parseQuery()
 		if err != nil {
 			return nil, errors.Errorf("parseQuery failed: %w", err)
 		}
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
 		}
 		inop = &sqlast.InSubQuery{
 			RParen:   r.To,
@@ -2161,9 +4322,9 @@ func (p *Parser) parseIn(expr sqlast.Node, negated bool) (sqlast.Node, error) {
 		if err != nil {
 			return nil, errors.Errorf("parseExprList failed: %w", err)
 		}
-		r, _ := p.nextToken()
-		if r.Kind != sqltoken.RParen {
-			return nil, errors.Errorf("expected RParen but %+v", r)
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
 		}
 		inop = &sqlast.InList{
 			RParen:  r.To,
@@ -2180,7 +4341,9 @@ func (p *Parser) parseBetween(expr sqlast.Node, negated bool) (sqlast.Node, erro
 	if err != nil {
 		return nil, errors.Errorf("parsePrefix: %w", err)
 	}
-	p.expectKeyword("AND")
+	if _, err := p.expectKeyword("AND"); err != nil {
+		return nil, err
+	}
 	high, err := p.parsePrefix()
 	if err != nil {
 		return nil, errors.Errorf("parsePrefix: %w", err)
@@ -2220,17 +4383,50 @@ func (p *Parser) getPrecedence(ts *sqltoken.Token) uint {
 			return 20
 		case "BETWEEN":
 			return 20
-		case "LIKE":
+		case "LIKE", "ILIKE", "SIMILAR", "REGEXP", "RLIKE":
 			return 20
 		default:
 			return 0
 		}
-	case sqltoken.Eq, sqltoken.Lt, sqltoken.LtEq, sqltoken.Neq, sqltoken.Gt, sqltoken.GtEq:
+	case sqltoken.Eq, sqltoken.Lt, sqltoken.LtEq, sqltoken.Neq, sqltoken.Gt, sqltoken.GtEq, sqltoken.Spaceship:
 		return 20
+	// bitwise operators follow MySQL where they have their own levels,
+	// otherwise they are "any other operator" of PostgreSQL like the rest
+	case sqltoken.Pipe, sqltoken.Sharp:
+		if p.dialect.Supports(dialect.BitwisePrecedence) {
+			return 21
+		}
+		return 24
+	case sqltoken.Ampersand:
+		if p.dialect.Supports(dialect.BitwisePrecedence) {
+			return 22
+		}
+		return 24
+	case sqltoken.ShiftLeft, sqltoken.ShiftRight:
+		if p.dialect.Supports(dialect.BitwisePrecedence) {
+			return 23
+		}
+		return 24
+	case sqltoken.DoublePipe:
+		if !p.dialect.Supports(dialect.ConcatOperator) && p.dialect.Supports(dialect.PipesAsOr) {
+			return 5
+		}
+		return 24
+	case sqltoken.Tilde, sqltoken.TildeAsterisk, sqltoken.ExclamationTilde, sqltoken.ExclamationTildeAsterisk,
+		sqltoken.Arrow, sqltoken.LongArrow, sqltoken.HashArrow, sqltoken.HashLongArrow,
+		sqltoken.AtArrow, sqltoken.ArrowAt, sqltoken.QuestionPipe, sqltoken.QuestionAmpersand, sqltoken.DoubleAmpersand:
+		return 24
+	case sqltoken.Placeholder:
+		if ts.Value == "?" {
+			return 24
+		}
+		return 0
 	case sqltoken.Plus, sqltoken.Minus:
 		return 30
 	case sqltoken.Mult, sqltoken.Div, sqltoken.Mod:
 		return 40
+	case sqltoken.Caret:
+		return 45
 	case sqltoken.DoubleColon:
 		return 50
 	default:
@@ -2302,7 +4498,22 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 				Expr: expr,
 			}, nil
 		default:
-			t, _ := p.peekToken()
+			if ph := newPlaceholder(tok); ph != nil {
+				return ph, nil
+			}
+			if lit, err := p.parseTypedLiteral(tok); err != nil || lit != nil {
+				return lit, err
+			}
+			if iv, err := p.parseInterval(tok); err != nil || iv != nil {
+				return iv, err
+			}
+			if sf, err := p.parseSpecialFunction(tok); err != nil || sf != nil {
+				return sf, err
+			}
+			if arr, err := p.parseArrayConstructor(tok); err != nil || arr != nil {
+				return arr, err
+			}
+			t, _ := p.peekToken()
 			if t == nil || (t.Kind != sqltoken.LParen && t.Kind != sqltoken.Period) {
 				return &sqlast.Ident{Value: word.String(),
 					From: tok.From,
@@ -2336,7 +4547,7 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 					break
 				}
 
-				return nil, errors.Errorf("an identifier or '*' after '.'")
+				return nil, p.expected(n, sqltoken.SQLKeyword, sqltoken.Mult)
 			}
 
 			if endWithWildcard {
@@ -2387,28 +4598,43 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 			Op:   &sqlast.Operator{Type: sqlast.Minus, From: tok.From, To: tok.To},
 			Expr: expr,
 		}, nil
-	case sqltoken.Number, sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral:
+	case sqltoken.Tilde:
+		// binds tighter than any binary operator but ::
+		expr, err := p.parseSubexpr(45)
+		if err != nil {
+			return nil, errors.Errorf("parseSubexpr failed: %w", err)
+		}
+		return &sqlast.UnaryExpr{
+			From: tok.From,
+			Op:   &sqlast.Operator{Type: sqlast.BitwiseNot, From: tok.From, To: tok.To},
+			Expr: expr,
+		}, nil
+	case sqltoken.Placeholder:
+		return newPlaceholder(tok), nil
+	case sqltoken.Number, sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral,
+		sqltoken.EscapedStringLiteral, sqltoken.BitStringLiteral, sqltoken.HexStringLiteral, sqltoken.DollarQuotedString:
 		p.prevToken()
 		v, err := p.parseSQLValue()
 		if err != nil {
-			return nil, errors.Errorf("parseSQLValue failed", err)
+			return nil, errors.Errorf("parseSQLValue failed: %w", err)
 		}
 		return v, nil
 	case sqltoken.LParen:
 		sok, _, _ := p.parseKeyword("SELECT")
 		wok, _, _ := p.parseKeyword("WITH")
+		vok, _, _ := p.parseKeyword("VALUES")
 
 		var ast sqlast.Node
 
-		if sok || wok {
+		if sok || wok || vok {
 			p.prevToken()
 			expr, err := p.parseQuery()
 			if err != nil {
 				return nil, errors.Errorf("parseQuery failed: %w", err)
 			}
-			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
-				return nil, errors.Errorf("expected RParen but %+v", r)
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
 			}
 			ast = &sqlast.SubQuery{
 				LParen: tok.From,
@@ -2420,9 +4646,9 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 			if err != nil {
 				return nil, errors.Errorf("parseQuery failed: %w", err)
 			}
-			r, _ := p.nextToken()
-			if r.Kind != sqltoken.RParen {
-				return nil, errors.Errorf("expected RParen but %+v", r)
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
 			}
 			ast = &sqlast.Nested{
 				LParen: tok.From,
@@ -2432,74 +4658,217 @@ func (p *Parser) parsePrefix() (sqlast.Node, error) {
 		}
 		return ast, nil
 	}
-	log.Panicf("prefix parser expected a keyword but hit EOF")
-	return nil, nil
+	return nil, p.errorf(tok, "expected an expression but found %s", tokenString(tok))
 }
 
 func (p *Parser) parseFunction(name *sqlast.ObjectName) (sqlast.Node, error) {
-	p.expectToken(sqltoken.LParen)
+	if _, err := p.expectToken(sqltoken.LParen); err != nil {
+		return nil, err
+	}
+	distinct, _, _ := p.parseKeyword("DISTINCT")
+	all := false
+	if !distinct {
+		all, _, _ = p.parseKeyword("ALL")
+	}
 	args, err := p.parseOptionalArgs()
 	if err != nil {
 		return nil, errors.Errorf("parseOptionalArgs failed: %w", err)
 	}
 
-	r, _ := p.nextToken()
-	if r.Kind != sqltoken.RParen {
-		return nil, errors.Errorf("expected RParen but %+v", r)
+	var orderBy []*sqlast.OrderByExpr
+	if ok, _, _ := p.parseKeywords("ORDER", "BY"); ok {
+		orderBy, err = p.parseOrderByExprList()
+		if err != nil {
+			return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
+		}
 	}
 
-	var over *sqlast.WindowSpec
-	if ok, _, _ := p.parseKeyword("OVER"); ok {
-		p.expectToken(sqltoken.LParen)
+	var separator *sqlast.SingleQuotedString
+	if ok, tok, _ := p.parseKeyword("SEPARATOR"); ok {
+		if !p.dialect.Supports(dialect.GroupConcatSeparator) {
+			return nil, p.unsupported(tok, "SEPARATOR")
+		}
+		t, err := p.expectToken(sqltoken.SingleQuotedString)
+		if err != nil {
+			return nil, err
+		}
+		separator = &sqlast.SingleQuotedString{
+			From:   t.From,
+			To:     t.To,
+			String: t.Value.(string),
+		}
+	}
 
-		var partitionBy []sqlast.Node
-		var partition sqltoken.Pos
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
 
-		ok, ptok, _ := p.parseKeyword("PARTITION")
-		if ok {
-			p.expectKeyword("BY")
+	f := &sqlast.Function{
+		Name:       name,
+		Distinct:   distinct,
+		All:        all,
+		Args:       args,
+		OrderBy:    orderBy,
+		Separator:  separator,
+		ArgsRParen: r.To,
+	}
+
+	if ok, _, _ := p.parseKeywords("WITHIN", "GROUP"); ok {
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectKeyword("ORDER"); err != nil {
+			return nil, err
+		}
+		if _, err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		f.WithinGroup, err = p.parseOrderByExprList()
+		if err != nil {
+			return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		f.WithinGroupRParen = r.To
+	}
 
-			el, err := p.parseExprList()
+	// FILTER without ( is a column alias
+	idx := p.index
+	if ok, ftok, _ := p.parseKeyword("FILTER"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			p.index = idx
+		} else {
+			if !p.dialect.Supports(dialect.AggregateFilter) {
+				return nil, p.unsupported(ftok, "FILTER (WHERE ...)")
+			}
+			if _, err := p.expectKeyword("WHERE"); err != nil {
+				return nil, err
+			}
+			f.Filter, err = p.ParseExpr()
 			if err != nil {
-				return nil, errors.Errorf("parseExprList failed: %w", err)
+				return nil, errors.Errorf("ParseExpr failed: %w", err)
+			}
+			r, err := p.expectToken(sqltoken.RParen)
+			if err != nil {
+				return nil, err
+			}
+			f.FilterRParen = r.To
+		}
+	}
+
+	if ok, _, _ := p.parseKeyword("OVER"); ok {
+		if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+			f.OverName, err = p.parseIdentifier()
+			if err != nil {
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
 			}
-			partitionBy = el
-			partition = ptok.From
+			return f, nil
+		}
+
+		f.Over, err = p.parseWindowSpec()
+		if err != nil {
+			return nil, errors.Errorf("parseWindowSpec failed: %w", err)
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+		f.OverRparen = r.To
+	}
+
+	return f, nil
+}
+
+// parseNamedWindowList parses `name AS (spec), ...` of WINDOW clause.
+func (p *Parser) parseNamedWindowList() ([]*sqlast.NamedWindow, error) {
+	var windows []*sqlast.NamedWindow
+
+	for {
+		name, err := p.parseIdentifier()
+		if err != nil {
+			return nil, errors.Errorf("parseIdentifier failed: %w", err)
+		}
+		if _, err := p.expectKeyword("AS"); err != nil {
+			return nil, err
 		}
+		if _, err := p.expectToken(sqltoken.LParen); err != nil {
+			return nil, err
+		}
+		spec, err := p.parseWindowSpec()
+		if err != nil {
+			return nil, errors.Errorf("parseWindowSpec failed: %w", err)
+		}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, &sqlast.NamedWindow{
+			Name:   name,
+			Spec:   spec,
+			RParen: r.To,
+		})
+
+		if ok, _ := p.consumeToken(sqltoken.Comma); !ok {
+			break
+		}
+	}
+
+	return windows, nil
+}
+
+// parseWindowSpec parses the window definition inside of the parentheses.
+func (p *Parser) parseWindowSpec() (*sqlast.WindowSpec, error) {
+	spec := &sqlast.WindowSpec{}
 
-		var orderBy []*sqlast.OrderByExpr
-		var order sqltoken.Pos
-		ok, otok, _ := p.parseKeyword("ORDER")
-		if ok {
-			p.expectKeyword("BY")
-			el, err := p.parseOrderByExprList()
+	// the first word which doesn't start any clause is the name of the base window
+	if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.SQLKeyword {
+		switch t.Value.(*sqltoken.SQLWord).Keyword {
+		case "PARTITION", "ORDER", "ROWS", "RANGE", "GROUPS":
+		default:
+			name, err := p.parseIdentifier()
 			if err != nil {
-				return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
+				return nil, errors.Errorf("parseIdentifier failed: %w", err)
 			}
-			orderBy = el
-			order = otok.From
+			spec.Name = name
 		}
+	}
 
-		windowFrame, err := p.parseWindowFrame()
+	if ok, ptok, _ := p.parseKeyword("PARTITION"); ok {
+		if _, err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+
+		el, err := p.parseExprList()
 		if err != nil {
-			return nil, errors.Errorf("parseWindowFrame failed: %w", err)
+			return nil, errors.Errorf("parseExprList failed: %w", err)
 		}
+		spec.PartitionBy = el
+		spec.Partition = ptok.From
+	}
 
-		over = &sqlast.WindowSpec{
-			PartitionBy:  partitionBy,
-			OrderBy:      orderBy,
-			WindowsFrame: windowFrame,
-			Partition:    partition,
-			Order:        order,
+	if ok, otok, _ := p.parseKeyword("ORDER"); ok {
+		if _, err := p.expectKeyword("BY"); err != nil {
+			return nil, err
 		}
+		el, err := p.parseOrderByExprList()
+		if err != nil {
+			return nil, errors.Errorf("parseOrderByExprList failed: %w", err)
+		}
+		spec.OrderBy = el
+		spec.Order = otok.From
 	}
 
-	return &sqlast.Function{
-		Name:       name,
-		Args:       args,
-		Over:       over,
-		ArgsRParen: r.To,
-	}, nil
+	windowFrame, err := p.parseWindowFrame()
+	if err != nil {
+		return nil, errors.Errorf("parseWindowFrame failed: %w", err)
+	}
+	spec.WindowsFrame = windowFrame
+
+	return spec, nil
 }
 
 func (p *Parser) parseOptionalArgs() ([]sqlast.Node, error) {
@@ -2523,20 +4892,44 @@ func (p *Parser) parseOrderByExprList() ([]*sqlast.OrderByExpr, error) {
 		if err != nil {
 			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
-		var asc *bool
+		o := &sqlast.OrderByExpr{
+			Expr: expr,
+		}
 
-		if ok, _, _ := p.parseKeyword("ASC"); ok {
+		if ok, tok, _ := p.parseKeyword("ASC"); ok {
 			b := true
-			asc = &b
-		} else if ok, _, _ := p.parseKeyword("DESC"); ok {
+			o.ASC = &b
+			o.OrderingPos = tok.To
+		} else if ok, tok, _ := p.parseKeyword("DESC"); ok {
 			b := false
-			asc = &b
+			o.ASC = &b
+			o.OrderingPos = tok.To
+		} else if ok, tok, _ := p.parseKeyword("USING"); ok {
+			if !p.dialect.Supports(dialect.OrderByUsing) {
+				return nil, p.unsupported(tok, "ORDER BY ... USING")
+			}
+			op, err := p.parseOrderingOperator()
+			if err != nil {
+				return nil, errors.Errorf("parseOrderingOperator failed: %w", err)
+			}
+			o.Using = op
 		}
 
-		exprList = append(exprList, &sqlast.OrderByExpr{
-			Expr: expr,
-			ASC:  asc,
-		})
+		if ok, _, _ := p.parseKeyword("NULLS"); ok {
+			var b bool
+			if ok, tok, _ := p.parseKeyword("FIRST"); ok {
+				b = true
+				o.NullsPos = tok.To
+			} else if ok, tok, _ := p.parseKeyword("LAST"); ok {
+				o.NullsPos = tok.To
+			} else {
+				tok, _ := p.peekToken()
+				return nil, p.expectedKeywords(tok, "FIRST", "LAST")
+			}
+			o.NullsFirst = &b
+		}
+
+		exprList = append(exprList, o)
 
 		if t, _ := p.peekToken(); t != nil && t.Kind == sqltoken.Comma {
 			p.mustNextToken()
@@ -2548,95 +4941,362 @@ func (p *Parser) parseOrderByExprList() ([]*sqlast.OrderByExpr, error) {
 	return exprList, nil
 }
 
+// parseOrderingOperator parses the comparison operator of ORDER BY ... USING.
+func (p *Parser) parseOrderingOperator() (*sqlast.Operator, error) {
+	tok, err := p.nextToken()
+	if err != nil {
+		return nil, errors.Errorf("nextToken failed: %w", err)
+	}
+
+	op := &sqlast.Operator{From: tok.From, To: tok.To}
+	switch tok.Kind {
+	case sqltoken.Lt:
+		op.Type = sqlast.Lt
+	case sqltoken.Gt:
+		op.Type = sqlast.Gt
+	case sqltoken.LtEq:
+		op.Type = sqlast.LtEq
+	case sqltoken.GtEq:
+		op.Type = sqlast.GtEq
+	default:
+		return nil, p.expected(tok, sqltoken.Lt, sqltoken.Gt, sqltoken.LtEq, sqltoken.GtEq)
+	}
+
+	return op, nil
+}
+
 func (p *Parser) parseWindowFrame() (*sqlast.WindowFrame, error) {
-	var windowFrame *sqlast.WindowFrame
 	t, _ := p.peekToken()
-	if t.Kind == sqltoken.SQLKeyword {
-		w := t.Value.(*sqltoken.SQLWord)
-		var u sqlast.WindowFrameUnit
+	if t == nil || t.Kind != sqltoken.SQLKeyword {
+		return nil, nil
+	}
+
+	var u sqlast.WindowFrameUnit
+	units, err := u.FromStr(t.Value.(*sqltoken.SQLWord).Keyword)
+	if err != nil {
+		return nil, p.expectedKeywords(t, "ROWS", "RANGE", "GROUPS")
+	}
+	p.mustNextToken()
+	units.From = t.From
+	units.To = t.To
+
+	windowFrame := &sqlast.WindowFrame{
+		Units: units,
+	}
+
+	if ok, _, _ := p.parseKeyword("BETWEEN"); ok {
+		startBound, err := p.parseWindowFrameBound()
+		if err != nil {
+			return nil, errors.Errorf("parseWindowFrameBound: %w", err)
+		}
+		if _, err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		endBound, err := p.parseWindowFrameBound()
+		if err != nil {
+			return nil, errors.Errorf("parseWindowFrameBound: %w", err)
+		}
+		windowFrame.StartBound = startBound
+		windowFrame.EndBound = endBound
+	} else {
+		startBound, err := p.parseWindowFrameBound()
+		if err != nil {
+			return nil, errors.Errorf("parseWindowFrameBound: %w", err)
+		}
+		windowFrame.StartBound = startBound
+	}
+
+	if ok, etok, _ := p.parseKeyword("EXCLUDE"); ok {
+		exclude, err := p.parseWindowFrameExclude()
+		if err != nil {
+			return nil, errors.Errorf("parseWindowFrameExclude failed: %w", err)
+		}
+		exclude.From = etok.From
+		windowFrame.Exclude = exclude
+	}
+
+	return windowFrame, nil
+}
+
+// parseWindowFrameExclude parses the rest of EXCLUDE { CURRENT ROW | GROUP | TIES | NO OTHERS }.
+func (p *Parser) parseWindowFrameExclude() (*sqlast.WindowFrameExclude, error) {
+	if ok, toks, _ := p.parseKeywords("CURRENT", "ROW"); ok {
+		return &sqlast.WindowFrameExclude{Type: sqlast.ExcludeCurrentRow, To: toks[1].To}, nil
+	}
+	if ok, tok, _ := p.parseKeyword("GROUP"); ok {
+		return &sqlast.WindowFrameExclude{Type: sqlast.ExcludeGroup, To: tok.To}, nil
+	}
+	if ok, tok, _ := p.parseKeyword("TIES"); ok {
+		return &sqlast.WindowFrameExclude{Type: sqlast.ExcludeTies, To: tok.To}, nil
+	}
+	if ok, toks, _ := p.parseKeywords("NO", "OTHERS"); ok {
+		return &sqlast.WindowFrameExclude{Type: sqlast.ExcludeNoOthers, To: toks[1].To}, nil
+	}
+
+	tok, _ := p.peekToken()
+	return nil, p.expectedKeywords(tok, "CURRENT ROW", "GROUP", "TIES", "NO OTHERS")
+}
+
+func (p *Parser) parseWindowFrameBound() (sqlast.SQLWindowFrameBound, error) {
+	if ok, toks, _ := p.parseKeywords("CURRENT", "ROW"); ok {
+		return &sqlast.CurrentRow{Current: toks[0].From, Row: toks[1].To}, nil
+	}
+
+	from, _ := p.peekToken()
+	if ok, _, _ := p.parseKeyword("UNBOUNDED"); ok {
+		if ok, tok, _ := p.parseKeyword("PRECEDING"); ok {
+			return &sqlast.UnboundedPreceding{Unbounded: from.From, Preceding: tok.To}, nil
+		}
+		if ok, tok, _ := p.parseKeyword("FOLLOWING"); ok {
+			return &sqlast.UnboundedFollowing{Unbounded: from.From, Following: tok.To}, nil
+		}
+		tok, _ := p.peekToken()
+		return nil, p.expectedKeywords(tok, "PRECEDING", "FOLLOWING")
+	}
+
+	// the offset is any expression such as `$1` or `INTERVAL '1 day'`
+	offset, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("invalid frame offset: %w", err)
+	}
+
+	if ok, tok, _ := p.parseKeyword("PRECEDING"); ok {
+		return &sqlast.Preceding{Bound: offset, From: from.From, Preceding: tok.To}, nil
+	}
+	if ok, tok, _ := p.parseKeyword("FOLLOWING"); ok {
+		return &sqlast.Following{Bound: offset, From: from.From, Following: tok.To}, nil
+	}
+	tok, _ := p.peekToken()
+	return nil, p.expectedKeywords(tok, "PRECEDING", "FOLLOWING")
+}
+
+func (p *Parser) parseObjectName() (*sqlast.ObjectName, error) {
+	idents, err := p.parseListOfIds(sqltoken.Period)
+	if err != nil {
+		return nil, errors.Errorf("parseListOfId: %w", err)
+	}
+	return &sqlast.ObjectName{
+		Idents: idents,
+	}, nil
+}
+
+var (
+	dateLayouts      = []string{"2006-01-02"}
+	timeLayouts      = withZoneLayouts("15:04:05", "15:04")
+	timestampLayouts = withZoneLayouts("2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04")
+)
+
+func withZoneLayouts(layouts ...string) []string {
+	var res []string
+	for _, l := range layouts {
+		res = append(res, l, l+"Z07:00", l+"-07", l+" Z07:00", l+" -07")
+	}
+	return res
+}
+
+// parseTimeLiteral returns zero time if s is not in any of layouts.
+// It is not an error because the database may accept other formats (e.g. 'now', 'Jan 1 2020').
+func parseTimeLiteral(s string, layouts []string) time.Time {
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseTypedLiteral parses `DATE '...'`, `TIME [WITH TIME ZONE] '...'` and `TIMESTAMP [WITH[OUT] TIME ZONE] '...'`.
+// It returns nil without consuming any tokens if tok does not start a typed literal.
+func (p *Parser) parseTypedLiteral(tok *sqltoken.Token) (sqlast.Node, error) {
+	word := tok.Value.(*sqltoken.SQLWord)
+	if word.QuoteStyle != 0 {
+		return nil, nil
+	}
+	switch word.Keyword {
+	case "DATE", "TIME", "TIMESTAMP":
+	default:
+		return nil, nil
+	}
+
+	idx := p.index
+	withTimeZone, withoutTimeZone := false, false
+	if word.Keyword != "DATE" {
+		if ok, _, _ := p.parseKeywords("WITH", "TIME", "ZONE"); ok {
+			withTimeZone = true
+		} else {
+			withoutTimeZone, _, _ = p.parseKeywords("WITHOUT", "TIME", "ZONE")
+		}
+	}
+
+	str, _ := p.peekToken()
+	if str == nil || str.Kind != sqltoken.SingleQuotedString {
+		p.index = idx
+		return nil, nil
+	}
+	p.mustNextToken()
+	raw := str.Value.(string)
+
+	switch {
+	case word.Keyword == "DATE":
+		return &sqlast.DateValue{
+			From: tok.From,
+			To:   str.To,
+			Date: parseTimeLiteral(raw, dateLayouts),
+			Raw:  raw,
+		}, nil
+	case word.Keyword == "TIME":
+		return &sqlast.TimeValue{
+			From:            tok.From,
+			To:              str.To,
+			Time:            parseTimeLiteral(raw, timeLayouts),
+			WithTimeZone:    withTimeZone,
+			WithoutTimeZone: withoutTimeZone,
+			Raw:             raw,
+		}, nil
+	case withTimeZone:
+		return &sqlast.TimestampValue{
+			From:      tok.From,
+			To:        str.To,
+			Timestamp: parseTimeLiteral(raw, timestampLayouts),
+			Raw:       raw,
+		}, nil
+	default:
+		return &sqlast.DateTimeValue{
+			From:            tok.From,
+			To:              str.To,
+			DateTime:        parseTimeLiteral(raw, timestampLayouts),
+			WithoutTimeZone: withoutTimeZone,
+			Raw:             raw,
+		}, nil
+	}
+}
+
+var intervalFields = map[string]struct{}{
+	"YEAR": {}, "MONTH": {}, "WEEK": {}, "DAY": {}, "HOUR": {}, "MINUTE": {}, "SECOND": {},
+	// MySQL units
+	"QUARTER": {}, "MICROSECOND": {}, "YEAR_MONTH": {},
+	"DAY_HOUR": {}, "DAY_MINUTE": {}, "DAY_SECOND": {}, "DAY_MICROSECOND": {},
+	"HOUR_MINUTE": {}, "HOUR_SECOND": {}, "HOUR_MICROSECOND": {},
+	"MINUTE_SECOND": {}, "MINUTE_MICROSECOND": {}, "SECOND_MICROSECOND": {},
+}
+
+// peekIntervalField reports whether the next token is an interval field.
+func (p *Parser) peekIntervalField() bool {
+	idx := p.index
+	_, tok := p.parseIntervalField()
+	p.index = idx
+	return tok != nil
+}
+
+// parseIntervalField consumes an interval field such as DAY if the next token is one.
+func (p *Parser) parseIntervalField() (string, *sqltoken.Token) {
+	tok, _ := p.peekToken()
+	if tok == nil || tok.Kind != sqltoken.SQLKeyword {
+		return "", nil
+	}
+	word := tok.Value.(*sqltoken.SQLWord)
+	if word.QuoteStyle != 0 {
+		return "", nil
+	}
+	if _, ok := intervalFields[word.Keyword]; !ok {
+		return "", nil
+	}
+	p.mustNextToken()
+	return word.Keyword, tok
+}
+
+// parseInterval parses `INTERVAL value [field [TO field]]`.
+// It returns nil without consuming any tokens if tok is not INTERVAL followed by a value,
+// so that `interval` can still be a column name.
+func (p *Parser) parseInterval(tok *sqltoken.Token) (sqlast.Node, error) {
+	word := tok.Value.(*sqltoken.SQLWord)
+	if word.QuoteStyle != 0 || word.Keyword != "INTERVAL" {
+		return nil, nil
+	}
+	t, _ := p.peekToken()
+	if t == nil {
+		return nil, nil
+	}
 
-		// FIXME
-		units, err := u.FromStr(w.Keyword)
+	// MySQL's `INTERVAL expr unit` takes any expression when a unit follows it
+	idx := p.index
+	value, err := p.parseSubexpr(0)
+	if err != nil || !p.peekIntervalField() {
+		p.index = idx
+		switch t.Kind {
+		case sqltoken.SingleQuotedString, sqltoken.Number, sqltoken.Minus, sqltoken.LParen, sqltoken.Placeholder:
+		default:
+			return nil, nil
+		}
+
+		value, err = p.parsePrefix()
+		if err != nil {
+			return nil, errors.Errorf("parsePrefix failed: %w", err)
+		}
+	}
+	iv := &sqlast.IntervalValue{
+		Interval: tok.From,
+		Value:    value,
+		To:       value.End(),
+	}
+
+	leading, ltok := p.parseIntervalField()
+	if ltok == nil {
+		return iv, nil
+	}
+	iv.LeadingField = leading
+	iv.To = ltok.To
+
+	if ok, _ := p.consumeToken(sqltoken.LParen); ok {
+		n, _, err := p.parseLiteralInt()
 		if err != nil {
-			return nil, errors.Errorf("invalid window frame unit: %w", err)
+			return nil, errors.Errorf("parseLiteralInt failed: %w", err)
 		}
-		p.mustNextToken()
+		precision := uint(n)
+		iv.LeadingPrecision = &precision
 
-		if ok, _, _ := p.parseKeyword("BETWEEN"); ok {
-			startBound, err := p.parseWindowFrameBound()
-			if err != nil {
-				return nil, errors.Errorf("parseWindowFrameBound: %w", err)
-			}
-			p.expectKeyword("AND")
-			endBound, err := p.parseWindowFrameBound()
-			if err != nil {
-				return nil, errors.Errorf("parseWindowFrameBound: %w", err)
+		if leading == "SECOND" {
+			if ok, _ := p.consumeToken(sqltoken.Comma); ok {
+				n, _, err := p.parseLiteralInt()
+				if err != nil {
+					return nil, errors.Errorf("parseLiteralInt failed: %w", err)
+				}
+				scale := uint(n)
+				iv.FractionalPrecision = &scale
 			}
+		}
 
-			windowFrame = &sqlast.WindowFrame{
-				StartBound: startBound,
-				EndBound:   endBound,
-				Units:      units,
-			}
-		} else {
-			startBound, err := p.parseWindowFrameBound()
-			if err != nil {
-				return nil, errors.Errorf("parseWindowFrameBound: %w", err)
-			}
-			windowFrame = &sqlast.WindowFrame{
-				StartBound: startBound,
-				Units:      units,
-			}
+		r, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, err
 		}
+		iv.To = r.To
 	}
 
-	p.expectToken(sqltoken.RParen)
-	return windowFrame, nil
-}
+	if ok, _, _ := p.parseKeyword("TO"); !ok {
+		return iv, nil
+	}
 
-func (p *Parser) parseWindowFrameBound() (sqlast.SQLWindowFrameBound, error) {
-	if ok, _, _ := p.parseKeywords("CURRENT", "ROW"); ok {
-		return &sqlast.CurrentRow{}, nil
+	trailing, ttok := p.parseIntervalField()
+	if ttok == nil {
+		t, _ := p.peekToken()
+		return nil, p.expectedKeywords(t, "YEAR", "MONTH", "DAY", "HOUR", "MINUTE", "SECOND")
 	}
+	iv.TrailingField = trailing
+	iv.To = ttok.To
 
-	var rows *uint64
-	if ok, _, _ := p.parseKeyword("UNBOUNDED"); ok {
-		if ok, _, _ := p.parseKeyword("PRECEDING"); ok {
-			return &sqlast.UnboundedPreceding{}, nil
-		}
-		if ok, _, _ := p.parseKeyword("FOLLOWING"); ok {
-			return &sqlast.UnboundedFollowing{}, nil
-		}
-	} else {
-		i, _, err := p.parseLiteralInt()
+	if trailing == "SECOND" {
+		precision, r, err := p.parseOptionalPrecision()
 		if err != nil {
-			return nil, errors.Errorf("parseLiteralInt failed: %w", err)
+			return nil, errors.Errorf("parseOptionalPrecision failed: %w", err)
 		}
-		if i < 0 {
-			return nil, errors.Errorf("the number of rows must ne non-negative, got %d", i)
+		if precision != nil {
+			iv.FractionalPrecision = precision
+			iv.To = r
 		}
-		ui := uint64(i)
-		rows = &ui
-	}
-
-	if ok, _, _ := p.parseKeyword("PRECEDING"); ok {
-		return &sqlast.Preceding{Bound: rows}, nil
-	}
-	if ok, _, _ := p.parseKeyword("FOLLOWING"); ok {
-		return &sqlast.Following{Bound: rows}, nil
 	}
-	log.Panicln("expected PRECEDING or FOLLOWING")
-	return nil, nil
-}
 
-func (p *Parser) parseObjectName() (*sqlast.ObjectName, error) {
-	idents, err := p.parseListOfIds(sqltoken.Period)
-	if err != nil {
-		return nil, errors.Errorf("parseListOfId: %w", err)
-	}
-	return &sqlast.ObjectName{
-		Idents: idents,
-	}, nil
+	return iv, nil
 }
 
 func (p *Parser) parseSQLValue() (sqlast.Node, error) {
@@ -2672,14 +5332,14 @@ func (p *Parser) parseValue() (sqlast.Node, error) {
 				To:   tok.To,
 			}, nil
 		default:
-			return nil, errors.Errorf("unexpected sqltoken %v", word)
+			return nil, p.expectedKeywords(tok, "TRUE", "FALSE", "NULL")
 		}
 	case sqltoken.Number:
 		num := tok.Value.(string)
 		if strings.Contains(num, ".") {
 			f, err := strconv.ParseFloat(num, 64)
 			if err != nil {
-				return nil, errors.Errorf("parseFloat failed %s", num)
+				return nil, p.errorf(tok, "invalid number %s", num)
 			}
 			return &sqlast.DoubleValue{
 				From:   tok.From,
@@ -2708,8 +5368,40 @@ func (p *Parser) parseValue() (sqlast.Node, error) {
 			From:   tok.From,
 			To:     tok.To,
 		}, nil
+	case sqltoken.EscapedStringLiteral:
+		str := tok.Value.(*sqltoken.PrefixedString)
+		return &sqlast.EscapedStringLiteral{
+			Prefix: str.Prefix,
+			String: str.Value,
+			From:   tok.From,
+			To:     tok.To,
+		}, nil
+	case sqltoken.BitStringLiteral:
+		str := tok.Value.(*sqltoken.PrefixedString)
+		return &sqlast.BitStringLiteral{
+			Prefix: str.Prefix,
+			String: str.Value,
+			From:   tok.From,
+			To:     tok.To,
+		}, nil
+	case sqltoken.HexStringLiteral:
+		str := tok.Value.(*sqltoken.PrefixedString)
+		return &sqlast.HexStringLiteral{
+			Prefix: str.Prefix,
+			String: str.Value,
+			From:   tok.From,
+			To:     tok.To,
+		}, nil
+	case sqltoken.DollarQuotedString:
+		q := tok.Value.(*sqltoken.DollarQuote)
+		return &sqlast.DollarQuotedString{
+			Tag:    q.Tag,
+			String: q.Value,
+			From:   tok.From,
+			To:     tok.To,
+		}, nil
 	default:
-		return nil, errors.Errorf("unexpected sqltoken %v", tok)
+		return nil, p.expected(tok, sqltoken.Number, sqltoken.SingleQuotedString, sqltoken.NationalStringLiteral)
 	}
 
 }
@@ -2720,10 +5412,9 @@ func (p *Parser) parseOptionalPrecision() (*uint, sqltoken.Pos, error) {
 		if err != nil {
 			return nil, sqltoken.Pos{}, errors.Errorf("parseLiteralInt failed: %w", err)
 		}
-		tok, _ := p.nextToken()
-
-		if tok.Kind != sqltoken.RParen {
-			return nil, sqltoken.Pos{}, errors.Errorf("expected RParen but %s", tok)
+		tok, err := p.expectToken(sqltoken.RParen)
+		if err != nil {
+			return nil, sqltoken.Pos{}, err
 		}
 		i := uint(n)
 		return &i, tok.To, nil
@@ -2749,20 +5440,22 @@ func (p *Parser) parseOptionalPrecisionScale() (*uint, *uint, error) {
 		us := uint(s)
 		scale = &us
 	}
-	p.expectToken(sqltoken.RParen)
+	if _, err := p.expectToken(sqltoken.RParen); err != nil {
+		return nil, nil, err
+	}
 	i := uint(n)
 	return &i, scale, nil
 }
 
 func (p *Parser) parseLiteralInt() (int, *sqltoken.Token, error) {
 	tok, _ := p.nextToken()
-	if tok.Kind != sqltoken.Number {
-		return 0, nil, errors.Errorf("expect literal int but %v", tok.Kind)
+	if tok == nil || tok.Kind != sqltoken.Number {
+		return 0, nil, p.expected(tok, sqltoken.Number)
 	}
 	istr := tok.Value.(string)
 	i, err := strconv.Atoi(istr)
 	if err != nil {
-		return 0, nil, errors.Errorf("strconv.Atoi failed: %w", err)
+		return 0, nil, p.errorf(tok, "expected an integer but found %s", istr)
 	}
 
 	return i, tok, nil
@@ -2795,7 +5488,8 @@ func (p *Parser) parseListOfIds(separator sqltoken.Kind) ([]*sqlast.Ident, error
 	}
 
 	if expectIdentifier {
-		return nil, errors.Errorf("expect identifier")
+		tok, _ := p.peekToken()
+		return nil, p.expected(tok, sqltoken.SQLKeyword)
 	}
 
 	return idents, nil
@@ -2804,7 +5498,7 @@ func (p *Parser) parseListOfIds(separator sqltoken.Kind) ([]*sqlast.Ident, error
 func (p *Parser) parseCaseExpression() (sqlast.Node, error) {
 	ok, tok, _ := p.parseKeyword("CASE")
 	if !ok {
-		return nil, errors.Errorf("expected CASE keyword but %s", tok)
+		return nil, p.expectedKeywords(tok, "CASE")
 	}
 
 	var operand sqlast.Node
@@ -2814,7 +5508,9 @@ func (p *Parser) parseCaseExpression() (sqlast.Node, error) {
 			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
 		operand = expr
-		p.expectKeyword("WHEN")
+		if _, err := p.expectKeyword("WHEN"); err != nil {
+			return nil, err
+		}
 	}
 
 	var conditions []sqlast.Node
@@ -2826,7 +5522,9 @@ func (p *Parser) parseCaseExpression() (sqlast.Node, error) {
 			return nil, errors.Errorf("ParseExpr failed: %w", err)
 		}
 		conditions = append(conditions, expr)
-		p.expectKeyword("THEN")
+		if _, err := p.expectKeyword("THEN"); err != nil {
+			return nil, err
+		}
 		result, err := p.ParseExpr()
 		if err != nil {
 			return nil, errors.Errorf("ParseExpr failed: %w", err)
@@ -2847,7 +5545,7 @@ func (p *Parser) parseCaseExpression() (sqlast.Node, error) {
 	}
 	ok, etok, _ := p.parseKeyword("END")
 	if !ok {
-		return nil, errors.Errorf("expect END keyword but %s", etok)
+		return nil, p.expectedKeywords(etok, "END")
 	}
 
 	return &sqlast.CaseExpr{
@@ -2864,21 +5562,25 @@ func (p *Parser) parseCaseExpression() (sqlast.Node, error) {
 func (p *Parser) parseCastExpression() (sqlast.Node, error) {
 	ok, tok, _ := p.parseKeyword("CAST")
 	if !ok {
-		return nil, errors.Errorf("expected CAST but %+v", tok)
+		return nil, p.expectedKeywords(tok, "CAST")
+	}
+	if _, err := p.expectToken(sqltoken.LParen); err != nil {
+		return nil, err
 	}
-	p.expectToken(sqltoken.LParen)
 	expr, err := p.ParseExpr()
 	if err != nil {
 		return nil, errors.Errorf("ParseExpr failed: %w", err)
 	}
-	p.expectKeyword("AS")
+	if _, err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
 	dataType, err := p.ParseDataType()
 	if err != nil {
-		return nil, errors.Errorf("ParseDataType")
+		return nil, errors.Errorf("ParseDataType failed: %w", err)
 	}
-	r, _ := p.nextToken()
-	if r.Kind != sqltoken.RParen {
-		return nil, errors.Errorf("expect RParen but %+v", r)
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
 	}
 
 	return &sqlast.Cast{
@@ -2889,21 +5591,294 @@ func (p *Parser) parseCastExpression() (sqlast.Node, error) {
 	}, nil
 }
 
+// parseSpecialFunction parses the special forms of EXTRACT, SUBSTRING, TRIM, POSITION, OVERLAY and GROUPING.
+// It returns nil without consuming any tokens if tok is not one of them
+// or the call has ordinary arguments such as SUBSTRING(s, 1, 2).
+// parseArrayConstructor parses ARRAY[elems...] after the ARRAY keyword tok.
+// It returns nil if tok does not start an array constructor.
+func (p *Parser) parseArrayConstructor(tok *sqltoken.Token) (sqlast.Node, error) {
+	word := tok.Value.(*sqltoken.SQLWord)
+	if word.QuoteStyle != 0 || word.Keyword != "ARRAY" {
+		return nil, nil
+	}
+	if t, _ := p.peekToken(); t == nil || t.Kind != sqltoken.LBracket {
+		return nil, nil
+	}
+	if !p.dialect.Supports(dialect.ArrayConstructor) {
+		return nil, p.unsupported(tok, "ARRAY[...]")
+	}
+	p.mustNextToken()
+
+	arr := &sqlast.ArrayConstructor{Array: tok.From}
+	if t, _ := p.peekToken(); t != nil && t.Kind != sqltoken.RBracket {
+		elems, err := p.parseExprList()
+		if err != nil {
+			return nil, errors.Errorf("parseExprList failed: %w", err)
+		}
+		arr.Elems = elems
+	}
+	r, err := p.expectToken(sqltoken.RBracket)
+	if err != nil {
+		return nil, err
+	}
+	arr.RBracket = r.To
+
+	return arr, nil
+}
+
+func (p *Parser) parseSpecialFunction(tok *sqltoken.Token) (sqlast.Node, error) {
+	word := tok.Value.(*sqltoken.SQLWord)
+	if word.QuoteStyle != 0 {
+		return nil, nil
+	}
+	switch word.Keyword {
+	case "EXTRACT", "SUBSTRING", "TRIM", "POSITION", "OVERLAY", "GROUPING":
+	default:
+		return nil, nil
+	}
+
+	idx := p.index
+	if ok, _ := p.consumeToken(sqltoken.LParen); !ok {
+		return nil, nil
+	}
+
+	var node sqlast.Node
+	var err error
+	switch word.Keyword {
+	case "EXTRACT":
+		node, err = p.parseExtract(tok)
+	case "SUBSTRING":
+		node, err = p.parseSubstring(tok)
+	case "TRIM":
+		node, err = p.parseTrim(tok)
+	case "POSITION":
+		node, err = p.parsePosition(tok)
+	case "OVERLAY":
+		node, err = p.parseOverlay(tok)
+	case "GROUPING":
+		node, err = p.parseGrouping(tok)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		p.index = idx
+	}
+	return node, nil
+}
+
+func (p *Parser) parseExtract(tok *sqltoken.Token) (sqlast.Node, error) {
+	field, _ := p.peekToken()
+	if field == nil || field.Kind != sqltoken.SQLKeyword || field.Value.(*sqltoken.SQLWord).QuoteStyle != 0 {
+		return nil, nil
+	}
+	p.mustNextToken()
+	if ok, _, _ := p.parseKeyword("FROM"); !ok {
+		return nil, nil
+	}
+
+	source, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlast.Extract{
+		Extract: tok.From,
+		Field:   field.Value.(*sqltoken.SQLWord).Keyword,
+		Source:  source,
+		RParen:  r.To,
+	}, nil
+}
+
+func (p *Parser) parseSubstring(tok *sqltoken.Token) (sqlast.Node, error) {
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+
+	var start, length sqlast.Node
+	if ok, _, _ := p.parseKeyword("FROM"); ok {
+		start, err = p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+	}
+	if ok, _, _ := p.parseKeyword("FOR"); ok {
+		length, err = p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+	}
+	if start == nil && length == nil {
+		return nil, nil
+	}
+
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlast.Substring{
+		Substring: tok.From,
+		Expr:      expr,
+		Start:     start,
+		Length:    length,
+		RParen:    r.To,
+	}, nil
+}
+
+func (p *Parser) parseTrim(tok *sqltoken.Token) (sqlast.Node, error) {
+	var where string
+	for _, kw := range []string{"LEADING", "TRAILING", "BOTH"} {
+		if ok, _, _ := p.parseKeyword(kw); ok {
+			where = kw
+			break
+		}
+	}
+
+	var chars sqlast.Node
+	if ok, _, _ := p.parseKeyword("FROM"); !ok {
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+		if ok, _, _ := p.parseKeyword("FROM"); !ok {
+			if where == "" {
+				return nil, nil
+			}
+			t, _ := p.peekToken()
+			return nil, p.expectedKeywords(t, "FROM")
+		}
+		chars = e
+	}
+
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlast.Trim{
+		Trim:   tok.From,
+		Where:  where,
+		Chars:  chars,
+		Expr:   expr,
+		RParen: r.To,
+	}, nil
+}
+
+func (p *Parser) parsePosition(tok *sqltoken.Token) (sqlast.Node, error) {
+	// stop before IN so that it is not parsed as `substr IN (...)`
+	substr, err := p.parseSubexpr(20)
+	if err != nil {
+		return nil, errors.Errorf("parseSubexpr failed: %w", err)
+	}
+	if ok, _, _ := p.parseKeyword("IN"); !ok {
+		return nil, nil
+	}
+
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlast.Position{
+		Position: tok.From,
+		Substr:   substr,
+		Expr:     expr,
+		RParen:   r.To,
+	}, nil
+}
+
+func (p *Parser) parseGrouping(tok *sqltoken.Token) (sqlast.Node, error) {
+	args, err := p.parseExprList()
+	if err != nil {
+		return nil, errors.Errorf("parseExprList failed: %w", err)
+	}
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlast.Grouping{
+		Grouping: tok.From,
+		Args:     args,
+		RParen:   r.To,
+	}, nil
+}
+
+func (p *Parser) parseOverlay(tok *sqltoken.Token) (sqlast.Node, error) {
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	if ok, _, _ := p.parseKeyword("PLACING"); !ok {
+		return nil, nil
+	}
+
+	placing, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+	if _, err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	start, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+
+	var length sqlast.Node
+	if ok, _, _ := p.parseKeyword("FOR"); ok {
+		length, err = p.ParseExpr()
+		if err != nil {
+			return nil, errors.Errorf("ParseExpr failed: %w", err)
+		}
+	}
+
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlast.Overlay{
+		Overlay: tok.From,
+		Expr:    expr,
+		Placing: placing,
+		Start:   start,
+		Length:  length,
+		RParen:  r.To,
+	}, nil
+}
+
 func (p *Parser) parseExistsExpression(negatedTok *sqltoken.Token) (sqlast.Node, error) {
 	ok, tok, _ := p.parseKeyword("EXISTS")
 	if !ok {
-		return nil, errors.Errorf("expect EXISTS but %+v", tok)
+		return nil, p.expectedKeywords(tok, "EXISTS")
 	}
 
-	p.expectToken(sqltoken.LParen)
+	if _, err := p.expectToken(sqltoken.LParen); err != nil {
+		return nil, err
+	}
 	expr, err := p.parseQuery()
 	if err != nil {
 		return nil, errors.Errorf("parseQuery failed: %w", err)
 	}
 
-	r, _ := p.nextToken()
-	if r.Kind != sqltoken.RParen {
-		return nil, errors.Errorf("expect RParen but %+v", r)
+	r, err := p.expectToken(sqltoken.RParen)
+	if err != nil {
+		return nil, err
 	}
 
 	if negatedTok != nil {
@@ -2924,6 +5899,9 @@ func (p *Parser) parseExistsExpression(negatedTok *sqltoken.Token) (sqlast.Node,
 }
 
 func (p *Parser) parseMyUnsigned() (bool, sqltoken.Pos) {
+	if !p.dialect.Supports(dialect.UnsignedInteger) {
+		return false, sqltoken.Pos{}
+	}
 	if ok, u, _ := p.parseKeyword("UNSIGNED"); ok {
 		return ok, u.To
 	}
@@ -2931,30 +5909,36 @@ func (p *Parser) parseMyUnsigned() (bool, sqltoken.Pos) {
 	return false, sqltoken.Pos{}
 }
 
-func (p *Parser) expectKeyword(expected string) *sqltoken.Token {
-	ok, tok, err := p.parseKeyword(expected)
-	if err != nil || !ok {
-		for i := 0; i < int(p.index); i++ {
-			fmt.Printf("%v", p.tokens[i].Value)
-		}
-		fmt.Println()
-		log.Panicf("should be expected keyword: %s err: %v", expected, err)
+func (p *Parser) expectKeyword(expected string) (*sqltoken.Token, error) {
+	ok, tok, _ := p.parseKeyword(expected)
+	if !ok {
+		return nil, p.expectedKeywords(tok, expected)
 	}
 
-	return tok
+	return tok, nil
 }
 
-func (p *Parser) expectToken(expected sqltoken.Kind) {
-	ok, err := p.consumeToken(expected)
-	if err != nil || !ok {
-		tok, _ := p.peekToken()
+func (p *Parser) expectToken(expected sqltoken.Kind) (*sqltoken.Token, error) {
+	tok, _ := p.peekToken()
+	if tok == nil || tok.Kind != expected {
+		return nil, p.expected(tok, expected)
+	}
+
+	return p.mustNextToken(), nil
+}
 
-		for i := 0; i < int(p.index); i++ {
-			fmt.Printf("%v", p.tokens[i].Value)
+// expectEOF consumes trailing semicolons and fails if any other token remains.
+func (p *Parser) expectEOF() error {
+	for {
+		if ok, _ := p.consumeToken(sqltoken.Semicolon); !ok {
+			break
 		}
-		fmt.Println()
-		log.Panicf("should be %s sqltoken, but %+v,  err: %+v", expected, tok, err)
 	}
+	tok, err := p.peekToken()
+	if err == EOF {
+		return nil
+	}
+	return p.errorf(tok, "unexpected %s after end of statement", tokenString(tok))
 }
 
 func (p *Parser) consumeToken(expected sqltoken.Kind) (bool, error) {
@@ -2973,12 +5957,9 @@ func (p *Parser) consumeToken(expected sqltoken.Kind) (bool, error) {
 	return false, nil
 }
 
+// mustNextToken consumes a token which has already been checked with peekToken.
 func (p *Parser) mustNextToken() *sqltoken.Token {
-	tok, err := p.nextToken()
-	if err != nil {
-		log.Panicf("%+v", err)
-	}
-
+	tok, _ := p.nextToken()
 	return tok
 }
 
@@ -3175,6 +6156,20 @@ func (p *Parser) parseKeyword(expected string) (bool, *sqltoken.Token, error) {
 	return false, tok, nil
 }
 
+// enter increases the nesting depth and checks it against MaxDepth.
+func (p *Parser) enter() error {
+	if p.maxDepth > 0 && p.depth >= p.maxDepth {
+		tok, _ := p.peekToken()
+		return p.errorf(tok, "nesting depth exceeds limit %d", p.maxDepth)
+	}
+	p.depth++
+	return nil
+}
+
+func (p *Parser) leave() {
+	p.depth--
+}
+
 func (p *Parser) Debug() {
 	for i := 0; i < int(p.index); i++ {
 		fmt.Printf("%v", p.tokens[i].Value)
@@ -494,6 +494,7 @@ ORDER BY product_units LIMIT 100`,
 						},
 					},
 					Limit: &sqlast.LimitExpr{
+						Limit: sqltoken.NewPos(4, 24),
 						LimitValue: &sqlast.LongValue{
 							From: sqltoken.NewPos(4, 30),
 							To:   sqltoken.NewPos(4, 33),
@@ -511,6 +512,7 @@ FROM orders
 WHERE region IN (SELECT region FROM top_regions)
 GROUP BY region, product`,
 				out: &sqlast.QueryStmt{
+					With: sqltoken.NewPos(1, 1),
 					CTEs: []*sqlast.CTE{
 						{
 							Alias: &sqlast.Ident{
@@ -578,6 +580,7 @@ GROUP BY region, product`,
 									},
 								},
 							},
+							RParen: sqltoken.NewPos(1, 95),
 						},
 					},
 					Body: &sqlast.SQLSelect{
@@ -1554,6 +1557,7 @@ FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)
 						sqlast.NewIdentWithPos("contract_name", sqltoken.NewPos(1, 39), sqltoken.NewPos(1, 52)),
 					},
 					Source: &sqlast.ConstructorSource{
+						Values: sqltoken.NewPos(1, 54),
 						Rows: []*sqlast.RowValueExpr{
 							{
 								LParen: sqltoken.NewPos(1, 60),
@@ -1596,6 +1600,7 @@ FOREIGN KEY(test_id) REFERENCES other_table(col1, col2)
 						sqlast.NewIdentWithPos("contract_name", sqltoken.NewPos(1, 39), sqltoken.NewPos(1, 52)),
 					},
 					Source: &sqlast.ConstructorSource{
+						Values: sqltoken.NewPos(1, 54),
 						Rows: []*sqlast.RowValueExpr{
 							{
 								LParen: sqltoken.NewPos(2, 1),
@@ -2212,7 +2217,7 @@ select 1 from test; /*lll*/ --mmm
 					t.Fatal(err)
 				}
 
-				if diff := cmp.Diff(c.out, stmt); diff != "" {
+				if diff := CompareWithoutMarker(c.out, stmt); diff != "" {
 					t.Errorf("diff %s", diff)
 				}
 
@@ -2225,3 +2230,102 @@ select 1 from test; /*lll*/ --mmm
 	})
 
 }
+
+func TestParser_RawStatements(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  []sqlast.Stmt
+	}{
+		{
+			name: "unknown keyword",
+			in:   "VACUUM  FULL t;\nSELECT a FROM t",
+			out: []sqlast.Stmt{
+				&sqlast.RawStmt{
+					Keyword: "VACUUM",
+					Text:    "VACUUM  FULL t",
+					From:    sqltoken.NewPos(1, 1),
+					To:      sqltoken.NewPos(1, 15),
+				},
+				&sqlast.QueryStmt{
+					Body: &sqlast.SQLSelect{
+						Select: sqltoken.NewPos(2, 1),
+						Projection: []sqlast.SQLSelectItem{
+							&sqlast.UnnamedSelectItem{
+								Node: sqlast.NewIdentWithPos("a", sqltoken.NewPos(2, 8), sqltoken.NewPos(2, 9)),
+							},
+						},
+						FromClause: []sqlast.TableReference{
+							&sqlast.Table{
+								Name: &sqlast.ObjectName{
+									Idents: []*sqlast.Ident{
+										sqlast.NewIdentWithPos("t", sqltoken.NewPos(2, 15), sqltoken.NewPos(2, 16)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unsupported create",
+			in:   "CREATE FUNCTION f() RETURNS int AS 'select 1' LANGUAGE sql",
+			out: []sqlast.Stmt{
+				&sqlast.RawStmt{
+					Keyword: "CREATE",
+					Text:    "CREATE FUNCTION f() RETURNS int AS 'select 1' LANGUAGE sql",
+					From:    sqltoken.NewPos(1, 1),
+					To:      sqltoken.NewPos(1, 59),
+				},
+			},
+		},
+		{
+			name: "unsupported alter and drop",
+			in:   "ALTER INDEX i RENAME TO j;\nDROP VIEW v;",
+			out: []sqlast.Stmt{
+				&sqlast.RawStmt{
+					Keyword: "ALTER",
+					Text:    "ALTER INDEX i RENAME TO j",
+					From:    sqltoken.NewPos(1, 1),
+					To:      sqltoken.NewPos(1, 26),
+				},
+				&sqlast.RawStmt{
+					Keyword: "DROP",
+					Text:    "DROP VIEW v",
+					From:    sqltoken.NewPos(2, 1),
+					To:      sqltoken.NewPos(2, 12),
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := NewParser(bytes.NewBufferString(c.in), &dialect.GenericSQLDialect{}, RawStatements())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stmts, err := parser.ParseSQL()
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if diff := CompareWithoutMarker(c.out, stmts); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		parser, err := NewParser(bytes.NewBufferString("VACUUM t"), &dialect.GenericSQLDialect{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := parser.ParseSQL(); err == nil {
+			t.Error("must be error")
+		}
+	})
+}
@@ -213,6 +213,81 @@ func (s *IsNotNull) WriteTo(w io.Writer) (int64, error) {
 	return newSQLWriter(w).Node(s.X).Bytes([]byte(" IS NOT NULL")).End()
 }
 
+// `X IS [NOT] DISTINCT FROM Y`
+type IsDistinctFrom struct {
+	X, Y    Node
+	Negated bool
+}
+
+func (s *IsDistinctFrom) Pos() sqltoken.Pos {
+	return s.X.Pos()
+}
+
+func (s *IsDistinctFrom) End() sqltoken.Pos {
+	return s.Y.End()
+}
+
+func (s *IsDistinctFrom) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *IsDistinctFrom) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(s.X).Bytes([]byte(" IS ")).
+		Negated(s.Negated).
+		Bytes([]byte("DISTINCT FROM ")).Node(s.Y).
+		End()
+}
+
+// `X IS [NOT] TRUE`, `X IS [NOT] FALSE` or `X IS [NOT] UNKNOWN`
+type IsBool struct {
+	X       Node
+	Negated bool
+	Value   string       // TRUE, FALSE or UNKNOWN
+	To      sqltoken.Pos // last position of Value
+}
+
+func (s *IsBool) Pos() sqltoken.Pos {
+	return s.X.Pos()
+}
+
+func (s *IsBool) End() sqltoken.Pos {
+	return s.To
+}
+
+func (s *IsBool) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *IsBool) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(s.X).Bytes([]byte(" IS ")).
+		Negated(s.Negated).
+		Bytes([]byte(s.Value)).
+		End()
+}
+
+// `Match ESCAPE Escape`
+// Match is a BinaryExpr of LIKE, ILIKE or SIMILAR TO (and their negations).
+type PatternEscape struct {
+	Match  *BinaryExpr
+	Escape Node
+}
+
+func (s *PatternEscape) Pos() sqltoken.Pos {
+	return s.Match.Pos()
+}
+
+func (s *PatternEscape) End() sqltoken.Pos {
+	return s.Escape.End()
+}
+
+func (s *PatternEscape) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *PatternEscape) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(s.Match).Bytes([]byte(" ESCAPE ")).Node(s.Escape).End()
+}
+
 // `Expr IN (List...)`
 type InList struct {
 	Expr    Node
@@ -356,6 +431,161 @@ func (s *Cast) WriteTo(w io.Writer) (int64, error) {
 		End()
 }
 
+// `EXTRACT(Field FROM Source)`
+type Extract struct {
+	Extract sqltoken.Pos // first position of EXTRACT token
+	Field   string       // e.g. YEAR, EPOCH
+	Source  Node
+	RParen  sqltoken.Pos
+}
+
+func (s *Extract) Pos() sqltoken.Pos {
+	return s.Extract
+}
+
+func (s *Extract) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Extract) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Extract) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).
+		Bytes([]byte("EXTRACT(")).Bytes([]byte(s.Field)).
+		Bytes(fromBytes).Node(s.Source).
+		RParen().
+		End()
+}
+
+// `SUBSTRING(Expr [FROM Start] [FOR Length])`
+// SUBSTRING with comma separated arguments is a Function.
+type Substring struct {
+	Substring sqltoken.Pos // first position of SUBSTRING token
+	Expr      Node
+	Start     Node // nullable
+	Length    Node // nullable
+	RParen    sqltoken.Pos
+}
+
+func (s *Substring) Pos() sqltoken.Pos {
+	return s.Substring
+}
+
+func (s *Substring) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Substring) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Substring) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).Bytes([]byte("SUBSTRING(")).Node(s.Expr)
+	if s.Start != nil {
+		sw.Bytes(fromBytes).Node(s.Start)
+	}
+	if s.Length != nil {
+		sw.Bytes([]byte(" FOR ")).Node(s.Length)
+	}
+	return sw.RParen().End()
+}
+
+// `TRIM([Where] [Chars] FROM Expr)`
+// TRIM without FROM is a Function.
+type Trim struct {
+	Trim   sqltoken.Pos // first position of TRIM token
+	Where  string       // LEADING, TRAILING, BOTH or empty
+	Chars  Node         // nullable
+	Expr   Node
+	RParen sqltoken.Pos
+}
+
+func (s *Trim) Pos() sqltoken.Pos {
+	return s.Trim
+}
+
+func (s *Trim) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Trim) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Trim) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).Bytes([]byte("TRIM("))
+	if s.Where != "" {
+		sw.Bytes([]byte(s.Where)).Space()
+	}
+	if s.Chars != nil {
+		sw.Node(s.Chars).Space()
+	}
+	return sw.Bytes([]byte("FROM ")).Node(s.Expr).RParen().End()
+}
+
+// `POSITION(Substr IN Expr)`
+type Position struct {
+	Position sqltoken.Pos // first position of POSITION token
+	Substr   Node
+	Expr     Node
+	RParen   sqltoken.Pos
+}
+
+func (s *Position) Pos() sqltoken.Pos {
+	return s.Position
+}
+
+func (s *Position) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Position) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Position) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).
+		Bytes([]byte("POSITION(")).Node(s.Substr).
+		Bytes([]byte(" IN ")).Node(s.Expr).
+		RParen().
+		End()
+}
+
+// `OVERLAY(Expr PLACING Placing FROM Start [FOR Length])`
+type Overlay struct {
+	Overlay sqltoken.Pos // first position of OVERLAY token
+	Expr    Node
+	Placing Node
+	Start   Node
+	Length  Node // nullable
+	RParen  sqltoken.Pos
+}
+
+func (s *Overlay) Pos() sqltoken.Pos {
+	return s.Overlay
+}
+
+func (s *Overlay) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Overlay) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Overlay) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).
+		Bytes([]byte("OVERLAY(")).Node(s.Expr).
+		Bytes([]byte(" PLACING ")).Node(s.Placing).
+		Bytes(fromBytes).Node(s.Start)
+	if s.Length != nil {
+		sw.Bytes([]byte(" FOR ")).Node(s.Length)
+	}
+	return sw.RParen().End()
+}
+
 // (AST)
 type Nested struct {
 	AST            Node
@@ -401,13 +631,69 @@ func (s *UnaryExpr) WriteTo(w io.Writer) (int64, error) {
 	return newSQLWriter(w).Node(s.Op).Space().Node(s.Expr).End()
 }
 
-// Name(Args...) [OVER (Over)]
+// `GROUPING(Args)` which tells whether Args are aggregated by ROLLUP, CUBE or GROUPING SETS
+type Grouping struct {
+	Grouping sqltoken.Pos // first position of GROUPING token
+	Args     []Node
+	RParen   sqltoken.Pos
+}
+
+func (s *Grouping) Pos() sqltoken.Pos {
+	return s.Grouping
+}
+
+func (s *Grouping) End() sqltoken.Pos {
+	return s.RParen
+}
+
+func (s *Grouping) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *Grouping) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("GROUPING(")).Nodes(s.Args).RParen().End()
+}
+
+// ARRAY[Elems...]
+type ArrayConstructor struct {
+	Array    sqltoken.Pos // first position of ARRAY keyword
+	Elems    []Node
+	RBracket sqltoken.Pos
+}
+
+func (s *ArrayConstructor) Pos() sqltoken.Pos {
+	return s.Array
+}
+
+func (s *ArrayConstructor) End() sqltoken.Pos {
+	return s.RBracket
+}
+
+func (s *ArrayConstructor) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *ArrayConstructor) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("ARRAY[")).Nodes(s.Elems).Bytes([]byte("]")).End()
+}
+
+// Name([DISTINCT | ALL] Args... [ORDER BY OrderBy...] [SEPARATOR Separator])
+// [WITHIN GROUP (ORDER BY WithinGroup...)] [FILTER (WHERE Filter)] [OVER (Over)]
 type Function struct {
-	Name       *ObjectName // Function Name
-	Args       []Node
-	ArgsRParen sqltoken.Pos // function args RParen position
-	Over       *WindowSpec
-	OverRparen sqltoken.Pos // Over RParen position (if Over is not nil)
+	Name              *ObjectName // Function Name
+	Distinct          bool
+	All               bool
+	Args              []Node
+	OrderBy           []*OrderByExpr      // e.g. string_agg(name, ',' ORDER BY name)
+	Separator         *SingleQuotedString // GROUP_CONCAT(name SEPARATOR ';') (MySQL)
+	ArgsRParen        sqltoken.Pos        // function args RParen position
+	WithinGroup       []*OrderByExpr
+	WithinGroupRParen sqltoken.Pos // WITHIN GROUP RParen position (if WithinGroup is not nil)
+	Filter            Node
+	FilterRParen      sqltoken.Pos // FILTER RParen position (if Filter is not nil)
+	Over              *WindowSpec
+	OverRparen        sqltoken.Pos // Over RParen position (if Over is not nil)
+	OverName          *Ident       // OVER window_name (referencing the WINDOW clause)
 }
 
 func (s *Function) Pos() sqltoken.Pos {
@@ -415,10 +701,19 @@ func (s *Function) Pos() sqltoken.Pos {
 }
 
 func (s *Function) End() sqltoken.Pos {
-	if s.Over == nil {
-		return s.ArgsRParen
+	if s.OverName != nil {
+		return s.OverName.End()
+	}
+	if s.Over != nil {
+		return s.OverRparen
+	}
+	if s.Filter != nil {
+		return s.FilterRParen
+	}
+	if len(s.WithinGroup) != 0 {
+		return s.WithinGroupRParen
 	}
-	return s.OverRparen
+	return s.ArgsRParen
 }
 
 func (s *Function) ToSQLString() string {
@@ -427,10 +722,36 @@ func (s *Function) ToSQLString() string {
 
 func (s *Function) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
-	sw.Node(s.Name).LParen().Nodes(s.Args).RParen()
+	sw.Node(s.Name).LParen().
+		If(s.Distinct, []byte("DISTINCT ")).
+		If(s.All, []byte("ALL ")).
+		Nodes(s.Args)
+	if len(s.OrderBy) != 0 {
+		sw.Bytes([]byte(" ORDER BY "))
+		for i, order := range s.OrderBy {
+			sw.JoinComma(i, order)
+		}
+	}
+	if s.Separator != nil {
+		sw.Bytes([]byte(" SEPARATOR ")).Node(s.Separator)
+	}
+	sw.RParen()
+	if len(s.WithinGroup) != 0 {
+		sw.Bytes([]byte(" WITHIN GROUP (ORDER BY "))
+		for i, order := range s.WithinGroup {
+			sw.JoinComma(i, order)
+		}
+		sw.RParen()
+	}
+	if s.Filter != nil {
+		sw.Bytes([]byte(" FILTER (WHERE ")).Node(s.Filter).RParen()
+	}
 	if s.Over != nil {
 		sw.Bytes([]byte(" OVER ")).LParen().Node(s.Over).RParen()
 	}
+	if s.OverName != nil {
+		sw.Bytes([]byte(" OVER ")).Node(s.OverName)
+	}
 	return sw.End()
 }
 
@@ -558,7 +879,10 @@ func (s *ObjectName) WriteTo(w io.Writer) (int64, error) {
 	return newSQLWriter(w).Idents(s.Idents, dotBytes).End()
 }
 
+// WindowSpec is the definition of a window in `OVER (...)` and `WINDOW name AS (...)`.
+// Name is set when the window is based on an existing one, e.g. `OVER (w ORDER BY a)`.
 type WindowSpec struct {
+	Name             *Ident
 	PartitionBy      []Node
 	OrderBy          []*OrderByExpr
 	WindowsFrame     *WindowFrame
@@ -566,14 +890,20 @@ type WindowSpec struct {
 }
 
 func (s *WindowSpec) Pos() sqltoken.Pos {
+	if s.Name != nil {
+		return s.Name.Pos()
+	}
 	if len(s.PartitionBy) != 0 {
 		return s.Partition
 	}
 	if len(s.OrderBy) != 0 {
 		return s.Order
 	}
+	if s.WindowsFrame != nil {
+		return s.WindowsFrame.Pos()
+	}
 
-	return s.WindowsFrame.Pos()
+	return sqltoken.Pos{}
 }
 
 func (s *WindowSpec) End() sqltoken.Pos {
@@ -585,7 +915,15 @@ func (s *WindowSpec) End() sqltoken.Pos {
 		return s.OrderBy[len(s.OrderBy)-1].End()
 	}
 
-	return s.PartitionBy[len(s.PartitionBy)-1].End()
+	if len(s.PartitionBy) != 0 {
+		return s.PartitionBy[len(s.PartitionBy)-1].End()
+	}
+
+	if s.Name != nil {
+		return s.Name.End()
+	}
+
+	return sqltoken.Pos{}
 }
 
 func (s *WindowSpec) ToSQLString() string {
@@ -595,8 +933,16 @@ func (s *WindowSpec) ToSQLString() string {
 func (s *WindowSpec) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	space := false
-	if len(s.PartitionBy) != 0 {
+	if s.Name != nil {
 		space = true
+		sw.Node(s.Name)
+	}
+	if len(s.PartitionBy) != 0 {
+		if space {
+			sw.Space()
+		} else {
+			space = true
+		}
 		sw.Bytes([]byte("PARTITION BY ")).Nodes(s.PartitionBy)
 	}
 	if len(s.OrderBy) != 0 {
@@ -623,6 +969,7 @@ type WindowFrame struct {
 	Units      *WindowFrameUnit
 	StartBound SQLWindowFrameBound
 	EndBound   SQLWindowFrameBound
+	Exclude    *WindowFrameExclude
 }
 
 func (s *WindowFrame) Pos() sqltoken.Pos {
@@ -630,6 +977,10 @@ func (s *WindowFrame) Pos() sqltoken.Pos {
 }
 
 func (s *WindowFrame) End() sqltoken.Pos {
+	if s.Exclude != nil {
+		return s.Exclude.End()
+	}
+
 	if s.EndBound != nil {
 		return s.EndBound.End()
 	}
@@ -644,12 +995,15 @@ func (s *WindowFrame) ToSQLString() string {
 func (s *WindowFrame) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	if s.EndBound != nil {
-		return sw.Node(s.Units).Bytes([]byte(" BETWEEN ")).
-			Node(s.StartBound).Bytes([]byte(" AND ")).Node(s.EndBound).
-			End()
+		sw.Node(s.Units).Bytes([]byte(" BETWEEN ")).
+			Node(s.StartBound).Bytes([]byte(" AND ")).Node(s.EndBound)
 	} else {
-		return sw.Node(s.Units).Space().Node(s.StartBound).End()
+		sw.Node(s.Units).Space().Node(s.StartBound)
+	}
+	if s.Exclude != nil {
+		sw.Space().Node(s.Exclude)
 	}
+	return sw.End()
 }
 
 type WindowFrameUnit struct {
@@ -714,6 +1068,47 @@ func (WindowFrameUnit) FromStr(str string) (*WindowFrameUnit, error) {
 	return nil, errors.Errorf("expected ROWS, RANGE, GROUPS but: %s", str)
 }
 
+// EXCLUDE { CURRENT ROW | GROUP | TIES | NO OTHERS } of window frame
+type WindowFrameExclude struct {
+	From, To sqltoken.Pos
+	Type     WindowFrameExcludeType
+}
+
+type WindowFrameExcludeType int
+
+const (
+	ExcludeCurrentRow WindowFrameExcludeType = iota
+	ExcludeGroup
+	ExcludeTies
+	ExcludeNoOthers
+)
+
+func (e *WindowFrameExclude) Pos() sqltoken.Pos {
+	return e.From
+}
+
+func (e *WindowFrameExclude) End() sqltoken.Pos {
+	return e.To
+}
+
+func (e *WindowFrameExclude) ToSQLString() string {
+	return toSQLString(e)
+}
+
+func (e *WindowFrameExclude) WriteTo(w io.Writer) (int64, error) {
+	switch e.Type {
+	case ExcludeCurrentRow:
+		return writeSingleBytes(w, []byte("EXCLUDE CURRENT ROW"))
+	case ExcludeGroup:
+		return writeSingleBytes(w, []byte("EXCLUDE GROUP"))
+	case ExcludeTies:
+		return writeSingleBytes(w, []byte("EXCLUDE TIES"))
+	case ExcludeNoOthers:
+		return writeSingleBytes(w, []byte("EXCLUDE NO OTHERS"))
+	}
+	return 0, nil
+}
+
 //go:generate genmark -t SQLWindowFrameBound -e Node
 
 type CurrentRow struct {
@@ -785,7 +1180,7 @@ func (u *UnboundedFollowing) WriteTo(w io.Writer) (int64, error) {
 // `Bound PRECEDING`
 type Preceding struct {
 	sqlWindowFrameBound
-	Bound     *uint64
+	Bound     Node
 	From      sqltoken.Pos // first char position of Bound
 	Preceding sqltoken.Pos // last char position of PRECEDING
 }
@@ -803,7 +1198,7 @@ func (p *Preceding) ToSQLString() string {
 }
 
 func (p *Preceding) WriteTo(w io.Writer) (n int64, err error) {
-	return newSQLWriter(w).Int(int(*p.Bound)).Bytes([]byte(" PRECEDING")).End()
+	return newSQLWriter(w).Node(p.Bound).Bytes([]byte(" PRECEDING")).End()
 }
 
 // `Bound FOLLOWING`
@@ -811,7 +1206,7 @@ type Following struct {
 	sqlWindowFrameBound
 	From      sqltoken.Pos // first char position of Bound
 	Following sqltoken.Pos // last char position of FOLLOWING
-	Bound     *uint64
+	Bound     Node
 }
 
 func (f *Following) Pos() sqltoken.Pos {
@@ -827,5 +1222,5 @@ func (f *Following) ToSQLString() string {
 }
 
 func (f *Following) WriteTo(w io.Writer) (n int64, err error) {
-	return newSQLWriter(w).Int(int(*f.Bound)).Bytes([]byte(" FOLLOWING")).End()
+	return newSQLWriter(w).Node(f.Bound).Bytes([]byte(" FOLLOWING")).End()
 }
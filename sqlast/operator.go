@@ -38,6 +38,38 @@ const (
 	Not
 	Like
 	NotLike
+	ILike
+	NotILike
+	SimilarTo
+	NotSimilarTo
+	Regexp
+	NotRegexp
+	RLike
+	NotRLike
+	StringConcat
+	BitwiseOr
+	BitwiseAnd
+	BitwiseXor // ^ except in PostgreSQL
+	BitwiseNot
+	PGBitwiseXor // #
+	ShiftLeft
+	ShiftRight
+	NullSafeEq // <=>
+	RegexMatch
+	RegexIMatch
+	RegexNotMatch
+	RegexNotIMatch
+	Arrow
+	LongArrow
+	HashArrow
+	HashLongArrow
+	AtArrow
+	ArrowAt
+	Question // jsonb key exists `?`
+	QuestionPipe
+	QuestionAnd
+	Overlap
+	Exponent // ^ of PostgreSQL
 	None
 )
 
@@ -75,6 +107,70 @@ func (o *Operator) ToSQLString() string {
 		return "LIKE"
 	case NotLike:
 		return "NOT LIKE"
+	case ILike:
+		return "ILIKE"
+	case NotILike:
+		return "NOT ILIKE"
+	case SimilarTo:
+		return "SIMILAR TO"
+	case NotSimilarTo:
+		return "NOT SIMILAR TO"
+	case Regexp:
+		return "REGEXP"
+	case NotRegexp:
+		return "NOT REGEXP"
+	case RLike:
+		return "RLIKE"
+	case NotRLike:
+		return "NOT RLIKE"
+	case StringConcat:
+		return "||"
+	case BitwiseOr:
+		return "|"
+	case BitwiseAnd:
+		return "&"
+	case BitwiseXor:
+		return "^"
+	case BitwiseNot:
+		return "~"
+	case PGBitwiseXor:
+		return "#"
+	case ShiftLeft:
+		return "<<"
+	case ShiftRight:
+		return ">>"
+	case NullSafeEq:
+		return "<=>"
+	case RegexMatch:
+		return "~"
+	case RegexIMatch:
+		return "~*"
+	case RegexNotMatch:
+		return "!~"
+	case RegexNotIMatch:
+		return "!~*"
+	case Arrow:
+		return "->"
+	case LongArrow:
+		return "->>"
+	case HashArrow:
+		return "#>"
+	case HashLongArrow:
+		return "#>>"
+	case AtArrow:
+		return "@>"
+	case ArrowAt:
+		return "<@"
+	case Question:
+		return "?"
+	case QuestionPipe:
+		return "?|"
+	case QuestionAnd:
+		return "?&"
+	case Overlap:
+		return "&&"
+	case Exponent:
+		return "^"
 	}
 	return ""
 }
@@ -113,6 +209,70 @@ func (o *Operator) WriteTo(w io.Writer) (int64, error) {
 		return writeSingleBytes(w, []byte("LIKE"))
 	case NotLike:
 		return writeSingleBytes(w, []byte("NOT LIKE"))
+	case ILike:
+		return writeSingleBytes(w, []byte("ILIKE"))
+	case NotILike:
+		return writeSingleBytes(w, []byte("NOT ILIKE"))
+	case SimilarTo:
+		return writeSingleBytes(w, []byte("SIMILAR TO"))
+	case NotSimilarTo:
+		return writeSingleBytes(w, []byte("NOT SIMILAR TO"))
+	case Regexp:
+		return writeSingleBytes(w, []byte("REGEXP"))
+	case NotRegexp:
+		return writeSingleBytes(w, []byte("NOT REGEXP"))
+	case RLike:
+		return writeSingleBytes(w, []byte("RLIKE"))
+	case NotRLike:
+		return writeSingleBytes(w, []byte("NOT RLIKE"))
+	case StringConcat:
+		return writeSingleBytes(w, []byte("||"))
+	case BitwiseOr:
+		return writeSingleBytes(w, []byte("|"))
+	case BitwiseAnd:
+		return writeSingleBytes(w, []byte("&"))
+	case BitwiseXor:
+		return writeSingleBytes(w, []byte("^"))
+	case BitwiseNot:
+		return writeSingleBytes(w, []byte("~"))
+	case PGBitwiseXor:
+		return writeSingleBytes(w, []byte("#"))
+	case ShiftLeft:
+		return writeSingleBytes(w, []byte("<<"))
+	case ShiftRight:
+		return writeSingleBytes(w, []byte(">>"))
+	case NullSafeEq:
+		return writeSingleBytes(w, []byte("<=>"))
+	case RegexMatch:
+		return writeSingleBytes(w, []byte("~"))
+	case RegexIMatch:
+		return writeSingleBytes(w, []byte("~*"))
+	case RegexNotMatch:
+		return writeSingleBytes(w, []byte("!~"))
+	case RegexNotIMatch:
+		return writeSingleBytes(w, []byte("!~*"))
+	case Arrow:
+		return writeSingleBytes(w, []byte("->"))
+	case LongArrow:
+		return writeSingleBytes(w, []byte("->>"))
+	case HashArrow:
+		return writeSingleBytes(w, []byte("#>"))
+	case HashLongArrow:
+		return writeSingleBytes(w, []byte("#>>"))
+	case AtArrow:
+		return writeSingleBytes(w, []byte("@>"))
+	case ArrowAt:
+		return writeSingleBytes(w, []byte("<@"))
+	case Question:
+		return writeSingleBytes(w, []byte("?"))
+	case QuestionPipe:
+		return writeSingleBytes(w, []byte("?|"))
+	case QuestionAnd:
+		return writeSingleBytes(w, []byte("?&"))
+	case Overlap:
+		return writeSingleBytes(w, []byte("&&"))
+	case Exponent:
+		return writeSingleBytes(w, []byte("^"))
 	}
 	return 0, nil
 }
\ No newline at end of file
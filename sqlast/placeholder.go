@@ -0,0 +1,81 @@
+package sqlast
+
+import (
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/moomou/xsqlparser/sqltoken"
+)
+
+type PlaceholderStyle int
+
+const (
+	QuestionPlaceholder   PlaceholderStyle = iota // ? or ?1
+	DollarPlaceholder                             // $1
+	ColonPlaceholder                              // :name
+	AtPlaceholder                                 // @name
+	DollarNamePlaceholder                         // $name (SQLite)
+)
+
+// Placeholder is a bind parameter.
+// Index is set for `$1` and `?1` style and Name is set for `:name`, `@name` and `$name` style.
+type Placeholder struct {
+	Style    PlaceholderStyle
+	Index    int
+	Name     string
+	From, To sqltoken.Pos
+}
+
+// IsNamed reports whether the placeholder is bound by name.
+func (p *Placeholder) IsNamed() bool {
+	return p.Style == ColonPlaceholder || p.Style == AtPlaceholder || p.Style == DollarNamePlaceholder
+}
+
+func (p *Placeholder) Pos() sqltoken.Pos {
+	return p.From
+}
+
+func (p *Placeholder) End() sqltoken.Pos {
+	return p.To
+}
+
+func (p *Placeholder) ToSQLString() string {
+	return toSQLString(p)
+}
+
+func (p *Placeholder) WriteTo(w io.Writer) (int64, error) {
+	switch p.Style {
+	case DollarPlaceholder:
+		return writeSingleString(w, "$"+strconv.Itoa(p.Index))
+	case ColonPlaceholder:
+		return writeSingleString(w, ":"+p.Name)
+	case AtPlaceholder:
+		return writeSingleString(w, "@"+p.Name)
+	case DollarNamePlaceholder:
+		return writeSingleString(w, "$"+p.Name)
+	default:
+		if p.Index != 0 {
+			return writeSingleString(w, "?"+strconv.Itoa(p.Index))
+		}
+		return writeSingleBytes(w, []byte("?"))
+	}
+}
+
+// Placeholders returns all placeholders in node in source order.
+func Placeholders(node Node) []*Placeholder {
+	var res []*Placeholder
+	Inspect(node, func(n Node) bool {
+		if p, ok := n.(*Placeholder); ok {
+			res = append(res, p)
+		}
+		return true
+	})
+
+	// Walk does not always visit nodes in source order
+	sort.SliceStable(res, func(i, j int) bool {
+		return sqltoken.ComparePos(res[i].From, res[j].From) < 0
+	})
+
+	return res
+}
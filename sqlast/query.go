@@ -10,11 +10,15 @@ import (
 // QueryStmt stmt
 type QueryStmt struct {
 	stmt
-	With    sqltoken.Pos // first char position of WITH if CTEs is not blank
-	CTEs    []*CTE
-	Body    SQLSetExpr
-	OrderBy []*OrderByExpr
-	Limit   *LimitExpr
+	With      sqltoken.Pos // first char position of WITH if CTEs is not blank
+	Recursive bool         // WITH RECURSIVE
+	CTEs      []*CTE
+	Body      SQLSetExpr
+	OrderBy   []*OrderByExpr
+	Limit     *LimitExpr
+	Locks     []*LockingClause
+	// LocksBeforeLimit is true if Locks are written before Limit like `FOR UPDATE LIMIT 1`
+	LocksBeforeLimit bool
 }
 
 func (q *QueryStmt) Pos() sqltoken.Pos {
@@ -26,6 +30,14 @@ func (q *QueryStmt) Pos() sqltoken.Pos {
 }
 
 func (q *QueryStmt) End() sqltoken.Pos {
+	if q.LocksBeforeLimit && q.Limit != nil {
+		return q.Limit.End()
+	}
+
+	if len(q.Locks) != 0 {
+		return q.Locks[len(q.Locks)-1].End()
+	}
+
 	if q.Limit != nil {
 		return q.Limit.End()
 	}
@@ -42,14 +54,7 @@ func (q *QueryStmt) ToSQLString() string {
 }
 
 func (q *QueryStmt) WriteTo(w io.Writer) (int64, error) {
-	sw := newSQLWriter(w)
-	if len(q.CTEs) != 0 {
-		sw.Bytes([]byte("WITH "))
-		for i, cte := range q.CTEs {
-			sw.JoinComma(i, cte)
-		}
-		sw.Space()
-	}
+	sw := newSQLWriter(w).With(q.Recursive, q.CTEs)
 	if sw.Err() == nil {
 		sw.Direct(q.Body.WriteTo(w))
 	}
@@ -59,17 +64,110 @@ func (q *QueryStmt) WriteTo(w io.Writer) (int64, error) {
 			sw.JoinComma(i, col)
 		}
 	}
-	if q.Limit != nil {
+	if q.Limit != nil && !q.LocksBeforeLimit {
 		sw.Space().Node(q.Limit)
 	}
+	for _, lock := range q.Locks {
+		sw.Space().Node(lock)
+	}
+	if q.Limit != nil && q.LocksBeforeLimit {
+		sw.Space().Node(q.Limit)
+	}
+	return sw.End()
+}
+
+// IsLocking reports whether the query or any query nested in it has a locking clause,
+// i.e. the query takes row locks like UPDATE does.
+func (q *QueryStmt) IsLocking() bool {
+	locking := false
+	Inspect(q, func(n Node) bool {
+		if s, ok := n.(*QueryStmt); ok && len(s.Locks) != 0 {
+			locking = true
+		}
+		return !locking
+	})
+	return locking
+}
+
+type LockStrength int
+
+const (
+	ForUpdate       LockStrength = iota // FOR UPDATE
+	ForNoKeyUpdate                      // FOR NO KEY UPDATE
+	ForShare                            // FOR SHARE
+	ForKeyShare                         // FOR KEY SHARE
+	LockInShareMode                     // LOCK IN SHARE MODE (MySQL)
+)
+
+type LockWait int
+
+const (
+	LockWaitDefault LockWait = iota
+	LockNoWait               // NOWAIT
+	LockSkipLocked           // SKIP LOCKED
+)
+
+// FOR Strength [OF Of...] [NOWAIT | SKIP LOCKED] or LOCK IN SHARE MODE
+type LockingClause struct {
+	From     sqltoken.Pos // first position of FOR or LOCK
+	Strength LockStrength
+	Of       []*ObjectName
+	Wait     LockWait
+	To       sqltoken.Pos // last position of the clause
+}
+
+func (l *LockingClause) Pos() sqltoken.Pos {
+	return l.From
+}
+
+func (l *LockingClause) End() sqltoken.Pos {
+	return l.To
+}
+
+func (l *LockingClause) ToSQLString() string {
+	return toSQLString(l)
+}
+
+func (l *LockingClause) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	switch l.Strength {
+	case ForUpdate:
+		sw.Bytes([]byte("FOR UPDATE"))
+	case ForNoKeyUpdate:
+		sw.Bytes([]byte("FOR NO KEY UPDATE"))
+	case ForShare:
+		sw.Bytes([]byte("FOR SHARE"))
+	case ForKeyShare:
+		sw.Bytes([]byte("FOR KEY SHARE"))
+	case LockInShareMode:
+		return sw.Bytes([]byte("LOCK IN SHARE MODE")).End()
+	}
+	if len(l.Of) != 0 {
+		sw.Bytes([]byte(" OF "))
+		for i, table := range l.Of {
+			sw.JoinComma(i, table)
+		}
+	}
+	switch l.Wait {
+	case LockNoWait:
+		sw.Bytes([]byte(" NOWAIT"))
+	case LockSkipLocked:
+		sw.Bytes([]byte(" SKIP LOCKED"))
+	}
 	return sw.End()
 }
 
-// CTE
+// CTE is `Alias [(Columns)] AS [[NOT] MATERIALIZED] (Query) [SEARCH ...] [CYCLE ...]`.
+// DML is set instead of Query for a data-modifying CTE like `AS (DELETE FROM ...)`.
 type CTE struct {
-	Alias  *Ident
-	Query  *QueryStmt
-	RParen sqltoken.Pos
+	Alias        *Ident
+	Columns      []*Ident
+	Materialized *bool // nil if neither MATERIALIZED nor NOT MATERIALIZED
+	Query        *QueryStmt
+	DML          Stmt // *InsertStmt, *UpdateStmt or *DeleteStmt
+	RParen       sqltoken.Pos
+	Search       *CTESearch
+	Cycle        *CTECycle
 }
 
 func (c *CTE) Pos() sqltoken.Pos {
@@ -77,6 +175,14 @@ func (c *CTE) Pos() sqltoken.Pos {
 }
 
 func (c *CTE) End() sqltoken.Pos {
+	if c.Cycle != nil {
+		return c.Cycle.End()
+	}
+
+	if c.Search != nil {
+		return c.Search.End()
+	}
+
 	return c.RParen
 }
 
@@ -85,11 +191,94 @@ func (c *CTE) ToSQLString() string {
 }
 
 func (c *CTE) WriteTo(w io.Writer) (int64, error) {
-	return newSQLWriter(w).
-		Node(c.Alias).As().LParen().Node(c.Query).RParen().
+	sw := newSQLWriter(w).Node(c.Alias)
+	if len(c.Columns) != 0 {
+		sw.Bytes([]byte(" (")).Idents(c.Columns, []byte(", ")).RParen()
+	}
+	sw.As()
+	if c.Materialized != nil {
+		sw.If(!*c.Materialized, []byte("NOT ")).Bytes([]byte("MATERIALIZED "))
+	}
+	sw.LParen()
+	if c.DML != nil {
+		sw.Node(c.DML)
+	} else {
+		sw.Node(c.Query)
+	}
+	sw.RParen()
+	if c.Search != nil {
+		sw.Space().Node(c.Search)
+	}
+	if c.Cycle != nil {
+		sw.Space().Node(c.Cycle)
+	}
+	return sw.End()
+}
+
+// SEARCH { BREADTH | DEPTH } FIRST BY Columns... SET Set
+type CTESearch struct {
+	Search  sqltoken.Pos // first position of SEARCH keyword
+	Breadth bool         // BREADTH FIRST if true, DEPTH FIRST otherwise
+	Columns []*Ident
+	Set     *Ident
+}
+
+func (s *CTESearch) Pos() sqltoken.Pos {
+	return s.Search
+}
+
+func (s *CTESearch) End() sqltoken.Pos {
+	return s.Set.End()
+}
+
+func (s *CTESearch) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *CTESearch) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).Bytes([]byte("SEARCH "))
+	if s.Breadth {
+		sw.Bytes([]byte("BREADTH"))
+	} else {
+		sw.Bytes([]byte("DEPTH"))
+	}
+	return sw.Bytes([]byte(" FIRST BY ")).Idents(s.Columns, []byte(", ")).
+		Bytes([]byte(" SET ")).Node(s.Set).
 		End()
 }
 
+// CYCLE Columns... SET Set [TO To DEFAULT Default] USING Using
+type CTECycle struct {
+	Cycle   sqltoken.Pos // first position of CYCLE keyword
+	Columns []*Ident
+	Set     *Ident
+	To      Node
+	Default Node
+	Using   *Ident
+}
+
+func (c *CTECycle) Pos() sqltoken.Pos {
+	return c.Cycle
+}
+
+func (c *CTECycle) End() sqltoken.Pos {
+	return c.Using.End()
+}
+
+func (c *CTECycle) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *CTECycle) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).
+		Bytes([]byte("CYCLE ")).Idents(c.Columns, []byte(", ")).
+		Bytes([]byte(" SET ")).Node(c.Set)
+	if c.To != nil {
+		sw.Bytes([]byte(" TO ")).Node(c.To).Bytes([]byte(" DEFAULT ")).Node(c.Default)
+	}
+	return sw.Bytes([]byte(" USING ")).Node(c.Using).End()
+}
+
 //go:generate genmark -t SQLSetExpr -e Node
 
 // Select
@@ -230,13 +419,20 @@ func (i *IntersectOperator) WriteTo(w io.Writer) (n int64, err error) {
 
 type SQLSelect struct {
 	sqlSetExpr
-	Distinct      bool
-	Projection    []SQLSelectItem
-	FromClause    []TableReference
-	WhereClause   Node
-	GroupByClause []Node
-	HavingClause  Node
-	Select        sqltoken.Pos // first position of SELECT
+	Distinct        bool            // true for both DISTINCT and DISTINCT ON; DistinctClause is not written if false
+	DistinctClause  *SelectDistinct // ALL, DISTINCT or DISTINCT ON (...)
+	Top             *Top            // T-SQL only
+	Projection      []SQLSelectItem
+	FromClause      []TableReference
+	WhereClause     Node
+	GroupByAll      bool // GROUP BY ALL ...
+	GroupByDistinct bool // GROUP BY DISTINCT ...
+	GroupByClause   []Node
+	WithRollup      bool         // GROUP BY ... WITH ROLLUP (MySQL)
+	WithRollupTo    sqltoken.Pos // last position of WITH ROLLUP
+	HavingClause    Node
+	WindowClause    []*NamedWindow
+	Select          sqltoken.Pos // first position of SELECT
 }
 
 func (s *SQLSelect) Pos() sqltoken.Pos {
@@ -244,10 +440,18 @@ func (s *SQLSelect) Pos() sqltoken.Pos {
 }
 
 func (s *SQLSelect) End() sqltoken.Pos {
+	if len(s.WindowClause) != 0 {
+		return s.WindowClause[len(s.WindowClause)-1].End()
+	}
+
 	if s.HavingClause != nil {
 		return s.HavingClause.End()
 	}
 
+	if s.WithRollup {
+		return s.WithRollupTo
+	}
+
 	if len(s.GroupByClause) != 0 {
 		return s.GroupByClause[len(s.GroupByClause)-1].End()
 	}
@@ -270,8 +474,16 @@ func (s *SQLSelect) ToSQLString() string {
 func (s *SQLSelect) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	sw.Bytes(selectBytes)
-	if s.Distinct {
+	switch {
+	case s.Distinct && s.DistinctClause != nil && s.DistinctClause.Type != SelectAll:
+		sw.Node(s.DistinctClause).Space()
+	case s.Distinct:
 		sw.Bytes([]byte("DISTINCT "))
+	case s.DistinctClause != nil && s.DistinctClause.Type == SelectAll:
+		sw.Node(s.DistinctClause).Space()
+	}
+	if s.Top != nil {
+		sw.Node(s.Top).Space()
 	}
 	for i, projection := range s.Projection {
 		sw.JoinComma(i, projection)
@@ -289,14 +501,226 @@ func (s *SQLSelect) WriteTo(w io.Writer) (int64, error) {
 		}
 	}
 	if len(s.GroupByClause) != 0 {
-		sw.Bytes([]byte(" GROUP BY ")).Nodes(s.GroupByClause)
+		sw.Bytes([]byte(" GROUP BY ")).
+			If(s.GroupByAll, []byte("ALL ")).
+			If(s.GroupByDistinct, []byte("DISTINCT ")).
+			Nodes(s.GroupByClause).
+			If(s.WithRollup, []byte(" WITH ROLLUP"))
 	}
 	if s.HavingClause != nil {
 		sw.Bytes([]byte(" HAVING ")).Node(s.HavingClause)
 	}
+	if len(s.WindowClause) != 0 {
+		sw.Bytes([]byte(" WINDOW "))
+		for i, window := range s.WindowClause {
+			sw.JoinComma(i, window)
+		}
+	}
 	return sw.End()
 }
 
+// `Name AS (Spec)` of WINDOW clause
+type NamedWindow struct {
+	Name   *Ident
+	Spec   *WindowSpec
+	RParen sqltoken.Pos
+}
+
+func (n *NamedWindow) Pos() sqltoken.Pos {
+	return n.Name.Pos()
+}
+
+func (n *NamedWindow) End() sqltoken.Pos {
+	return n.RParen
+}
+
+func (n *NamedWindow) ToSQLString() string {
+	return toSQLString(n)
+}
+
+func (n *NamedWindow) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(n.Name).Bytes([]byte(" AS ")).
+		LParen().Node(n.Spec).RParen().
+		End()
+}
+
+// ROLLUP (Elements) of GROUP BY
+type Rollup struct {
+	Rollup   sqltoken.Pos // first position of ROLLUP keyword
+	Elements []Node
+	RParen   sqltoken.Pos
+}
+
+func (r *Rollup) Pos() sqltoken.Pos {
+	return r.Rollup
+}
+
+func (r *Rollup) End() sqltoken.Pos {
+	return r.RParen
+}
+
+func (r *Rollup) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *Rollup) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("ROLLUP (")).Nodes(r.Elements).RParen().End()
+}
+
+// CUBE (Elements) of GROUP BY
+type Cube struct {
+	Cube     sqltoken.Pos // first position of CUBE keyword
+	Elements []Node
+	RParen   sqltoken.Pos
+}
+
+func (c *Cube) Pos() sqltoken.Pos {
+	return c.Cube
+}
+
+func (c *Cube) End() sqltoken.Pos {
+	return c.RParen
+}
+
+func (c *Cube) ToSQLString() string {
+	return toSQLString(c)
+}
+
+func (c *Cube) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("CUBE (")).Nodes(c.Elements).RParen().End()
+}
+
+// GROUPING SETS (Sets) of GROUP BY
+type GroupingSets struct {
+	Grouping sqltoken.Pos // first position of GROUPING keyword
+	Sets     []Node
+	RParen   sqltoken.Pos
+}
+
+func (g *GroupingSets) Pos() sqltoken.Pos {
+	return g.Grouping
+}
+
+func (g *GroupingSets) End() sqltoken.Pos {
+	return g.RParen
+}
+
+func (g *GroupingSets) ToSQLString() string {
+	return toSQLString(g)
+}
+
+func (g *GroupingSets) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte("GROUPING SETS (")).Nodes(g.Sets).RParen().End()
+}
+
+// `(Exprs)` of grouping elements like `GROUPING SETS ((a, b), ())`.
+// Exprs is empty for the grand total `()`.
+type GroupingSet struct {
+	LParen, RParen sqltoken.Pos
+	Exprs          []Node
+}
+
+func (g *GroupingSet) Pos() sqltoken.Pos {
+	return g.LParen
+}
+
+func (g *GroupingSet) End() sqltoken.Pos {
+	return g.RParen
+}
+
+func (g *GroupingSet) ToSQLString() string {
+	return toSQLString(g)
+}
+
+func (g *GroupingSet) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).LParen().Nodes(g.Exprs).RParen().End()
+}
+
+type SelectDistinctType int
+
+const (
+	SelectAll          SelectDistinctType = iota // ALL
+	SelectDistinctRows                           // DISTINCT
+	SelectDistinctOn                             // DISTINCT ON (...) (PostgreSQL)
+)
+
+// ALL, DISTINCT or DISTINCT ON (On...) of SELECT
+type SelectDistinct struct {
+	Type     SelectDistinctType
+	On       []Node
+	From, To sqltoken.Pos // To is the last position of the clause
+}
+
+func (d *SelectDistinct) Pos() sqltoken.Pos {
+	return d.From
+}
+
+func (d *SelectDistinct) End() sqltoken.Pos {
+	return d.To
+}
+
+func (d *SelectDistinct) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *SelectDistinct) WriteTo(w io.Writer) (int64, error) {
+	switch d.Type {
+	case SelectAll:
+		return writeSingleBytes(w, []byte("ALL"))
+	case SelectDistinctOn:
+		return newSQLWriter(w).Bytes([]byte("DISTINCT ON (")).Nodes(d.On).RParen().End()
+	}
+	return writeSingleBytes(w, []byte("DISTINCT"))
+}
+
+// TOP (n) [PERCENT] [WITH TIES] of T-SQL
+type Top struct {
+	Top      sqltoken.Pos
+	LParen   sqltoken.Pos // zero if the count is not parenthesized like `TOP 5`
+	Expr     Node
+	Percent  bool
+	WithTies bool
+	To       sqltoken.Pos // last position of the clause
+}
+
+func (t *Top) Pos() sqltoken.Pos {
+	return t.Top
+}
+
+func (t *Top) End() sqltoken.Pos {
+	return t.To
+}
+
+func (t *Top) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *Top) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).Bytes([]byte("TOP "))
+	if t.parenthesized() {
+		sw.LParen().Node(t.Expr).RParen()
+	} else {
+		sw.Node(t.Expr)
+	}
+	return sw.
+		If(t.Percent, []byte(" PERCENT")).
+		If(t.WithTies, []byte(" WITH TIES")).
+		End()
+}
+
+// parenthesized reports whether the count is written in parentheses.
+// Only a number can be written without them.
+func (t *Top) parenthesized() bool {
+	if t.LParen != (sqltoken.Pos{}) {
+		return true
+	}
+	switch t.Expr.(type) {
+	case *LongValue, *DoubleValue:
+		return false
+	}
+	return true
+}
+
 //go:generate genmark -t TableReference -e Node
 
 //go:generate genmark -t TableFactor -e TableReference
@@ -306,9 +730,11 @@ type Table struct {
 	tableFactor
 	tableReference
 	Name            *ObjectName
+	SystemTime      *SystemTime
 	Alias           *Ident
-	Args            []Node
-	ArgsRParen      sqltoken.Pos
+	Columns         []*Ident     // column aliases like `t (a, b)`
+	ColumnsRParen   sqltoken.Pos // last position of the column aliases
+	Sample          *TableSample
 	WithHints       []Node
 	WithHintsRParen sqltoken.Pos
 }
@@ -322,12 +748,20 @@ func (t *Table) End() sqltoken.Pos {
 		return t.WithHintsRParen
 	}
 
+	if t.Sample != nil {
+		return t.Sample.End()
+	}
+
+	if len(t.Columns) != 0 {
+		return t.ColumnsRParen
+	}
+
 	if t.Alias != nil {
 		return t.Alias.End()
 	}
 
-	if len(t.Args) != 0 {
-		return t.ArgsRParen
+	if t.SystemTime != nil {
+		return t.SystemTime.End()
 	}
 
 	return t.Name.End()
@@ -340,12 +774,18 @@ func (t *Table) ToSQLString() string {
 func (t *Table) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	sw.Node(t.Name)
-	if len(t.Args) != 0 {
-		sw.LParen().Nodes(t.Args).RParen()
+	if t.SystemTime != nil {
+		sw.Space().Node(t.SystemTime)
 	}
 	if t.Alias != nil {
 		sw.As().Node(t.Alias)
 	}
+	if len(t.Columns) != 0 {
+		sw.Bytes([]byte(" (")).Idents(t.Columns, []byte(", ")).RParen()
+	}
+	if t.Sample != nil {
+		sw.Space().Node(t.Sample)
+	}
 	if len(t.WithHints) != 0 {
 		sw.Bytes([]byte(" WITH ")).LParen().Nodes(t.WithHints).RParen()
 	}
@@ -355,12 +795,14 @@ func (t *Table) WriteTo(w io.Writer) (int64, error) {
 type Derived struct {
 	tableFactor
 	tableReference
-	Lateral    bool
-	LateralPos sqltoken.Pos // last position of LATERAL keyword if Lateral is true
-	LParen     sqltoken.Pos
-	RParen     sqltoken.Pos
-	SubQuery   *QueryStmt
-	Alias      *Ident
+	Lateral       bool
+	LateralPos    sqltoken.Pos // first position of LATERAL keyword if Lateral is true
+	LParen        sqltoken.Pos
+	RParen        sqltoken.Pos
+	SubQuery      *QueryStmt
+	Alias         *Ident
+	Columns       []*Ident     // column aliases like `(VALUES ...) AS t (a, b)`
+	ColumnsRParen sqltoken.Pos // last position of the column aliases
 }
 
 func (d *Derived) Pos() sqltoken.Pos {
@@ -371,11 +813,15 @@ func (d *Derived) Pos() sqltoken.Pos {
 }
 
 func (d *Derived) End() sqltoken.Pos {
+	if len(d.Columns) != 0 {
+		return d.ColumnsRParen
+	}
+
 	if d.Alias != nil {
 		return d.Alias.End()
 	}
 
-	return d.LParen
+	return d.RParen
 }
 
 func (d *Derived) ToSQLString() string {
@@ -389,6 +835,213 @@ func (d *Derived) WriteTo(w io.Writer) (int64, error) {
 	if d.Alias != nil {
 		sw.As().Node(d.Alias)
 	}
+	if len(d.Columns) != 0 {
+		sw.Bytes([]byte(" (")).Idents(d.Columns, []byte(", ")).RParen()
+	}
+	return sw.End()
+}
+
+// TableFunction is a function call in FROM like `unnest(arr) WITH ORDINALITY AS u (x, n)`.
+// Functions has more than one function only for `ROWS FROM (f(a), g(b))`.
+type TableFunction struct {
+	tableFactor
+	tableReference
+	Lateral        bool
+	LateralPos     sqltoken.Pos // first position of LATERAL keyword if Lateral is true
+	RowsFrom       bool
+	RowsFromPos    sqltoken.Pos // first position of ROWS keyword if RowsFrom is true
+	RowsFromRParen sqltoken.Pos
+	Functions      []*Function
+	WithOrdinality bool
+	OrdinalityPos  sqltoken.Pos // last position of ORDINALITY keyword if WithOrdinality is true
+	Alias          *Ident
+	Columns        []*Ident               // column aliases like `AS t (a, b)`
+	ColumnDefs     []*TableFunctionColumn // column definition list like `AS t (a int, b text)`
+	ColumnsRParen  sqltoken.Pos           // last position of Columns or ColumnDefs
+}
+
+func (t *TableFunction) Pos() sqltoken.Pos {
+	if t.Lateral {
+		return t.LateralPos
+	}
+	if t.RowsFrom {
+		return t.RowsFromPos
+	}
+	return t.Functions[0].Pos()
+}
+
+func (t *TableFunction) End() sqltoken.Pos {
+	if len(t.Columns) != 0 || len(t.ColumnDefs) != 0 {
+		return t.ColumnsRParen
+	}
+	if t.Alias != nil {
+		return t.Alias.End()
+	}
+	if t.WithOrdinality {
+		return t.OrdinalityPos
+	}
+	if t.RowsFrom {
+		return t.RowsFromRParen
+	}
+	return t.Functions[0].End()
+}
+
+func (t *TableFunction) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *TableFunction) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.If(t.Lateral, []byte("LATERAL "))
+	if t.RowsFrom {
+		sw.Bytes([]byte("ROWS FROM ")).LParen()
+		for i, f := range t.Functions {
+			sw.JoinComma(i, f)
+		}
+		sw.RParen()
+	} else {
+		sw.Node(t.Functions[0])
+	}
+	sw.If(t.WithOrdinality, []byte(" WITH ORDINALITY"))
+	if t.Alias != nil {
+		sw.As().Node(t.Alias)
+	}
+	if len(t.Columns) != 0 {
+		sw.Bytes([]byte(" (")).Idents(t.Columns, []byte(", ")).RParen()
+	}
+	if len(t.ColumnDefs) != 0 {
+		if t.Alias != nil {
+			sw.Space()
+		} else {
+			sw.As()
+		}
+		sw.LParen()
+		for i, c := range t.ColumnDefs {
+			sw.JoinComma(i, c)
+		}
+		sw.RParen()
+	}
+	return sw.End()
+}
+
+// TableFunctionColumn is an element of the column definition list of TableFunction.
+type TableFunctionColumn struct {
+	Name     *Ident
+	DataType Type
+}
+
+func (t *TableFunctionColumn) Pos() sqltoken.Pos {
+	return t.Name.Pos()
+}
+
+func (t *TableFunctionColumn) End() sqltoken.Pos {
+	return t.DataType.End()
+}
+
+func (t *TableFunctionColumn) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *TableFunctionColumn) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Node(t.Name).Space().Node(t.DataType).End()
+}
+
+type TableSampleUnit int
+
+const (
+	SampleNoUnit  TableSampleUnit = iota
+	SamplePercent                 // PERCENT (SQL Server)
+	SampleRows                    // ROWS (SQL Server)
+)
+
+// TABLESAMPLE [Method] (Args... [Unit]) [REPEATABLE (Seed)]
+// Method is nil for `TABLESAMPLE (10 PERCENT)` of SQL Server.
+type TableSample struct {
+	From   sqltoken.Pos // first position of TABLESAMPLE keyword
+	Method *Ident       // e.g. BERNOULLI, SYSTEM
+	Args   []Node
+	Unit   TableSampleUnit
+	Seed   Node
+	To     sqltoken.Pos // last position of the clause
+}
+
+func (t *TableSample) Pos() sqltoken.Pos {
+	return t.From
+}
+
+func (t *TableSample) End() sqltoken.Pos {
+	return t.To
+}
+
+func (t *TableSample) ToSQLString() string {
+	return toSQLString(t)
+}
+
+func (t *TableSample) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).Bytes([]byte("TABLESAMPLE "))
+	if t.Method != nil {
+		sw.Node(t.Method).Space()
+	}
+	sw.LParen().Nodes(t.Args)
+	switch t.Unit {
+	case SamplePercent:
+		sw.Bytes([]byte(" PERCENT"))
+	case SampleRows:
+		sw.Bytes([]byte(" ROWS"))
+	}
+	sw.RParen()
+	if t.Seed != nil {
+		sw.Bytes([]byte(" REPEATABLE (")).Node(t.Seed).RParen()
+	}
+	return sw.End()
+}
+
+type SystemTimeType int
+
+const (
+	SystemTimeAsOf        SystemTimeType = iota // AS OF Value
+	SystemTimeFromTo                            // FROM Start TO Stop
+	SystemTimeBetween                           // BETWEEN Start AND Stop
+	SystemTimeContainedIn                       // CONTAINED IN (Start, Stop)
+	SystemTimeAll                               // ALL
+)
+
+// FOR SYSTEM_TIME of a temporal table
+type SystemTime struct {
+	From  sqltoken.Pos // first position of FOR keyword
+	Type  SystemTimeType
+	Value Node // AS OF Value
+	Start Node
+	Stop  Node
+	To    sqltoken.Pos // last position of the clause
+}
+
+func (s *SystemTime) Pos() sqltoken.Pos {
+	return s.From
+}
+
+func (s *SystemTime) End() sqltoken.Pos {
+	return s.To
+}
+
+func (s *SystemTime) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SystemTime) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).Bytes([]byte("FOR SYSTEM_TIME "))
+	switch s.Type {
+	case SystemTimeAsOf:
+		sw.Bytes([]byte("AS OF ")).Node(s.Value)
+	case SystemTimeFromTo:
+		sw.Bytes([]byte("FROM ")).Node(s.Start).Bytes([]byte(" TO ")).Node(s.Stop)
+	case SystemTimeBetween:
+		sw.Bytes([]byte("BETWEEN ")).Node(s.Start).Bytes([]byte(" AND ")).Node(s.Stop)
+	case SystemTimeContainedIn:
+		sw.Bytes([]byte("CONTAINED IN (")).Node(s.Start).Bytes([]byte(", ")).Node(s.Stop).RParen()
+	case SystemTimeAll:
+		sw.Bytes([]byte("ALL"))
+	}
 	return sw.End()
 }
 
@@ -507,6 +1160,37 @@ func (c *CrossJoin) WriteTo(w io.Writer) (int64, error) {
 		End()
 }
 
+// CROSS APPLY or OUTER APPLY of T-SQL
+type ApplyJoin struct {
+	tableReference
+	Reference TableReference
+	Outer     bool
+	Factor    TableFactor
+}
+
+func (a *ApplyJoin) Pos() sqltoken.Pos {
+	return a.Reference.Pos()
+}
+
+func (a *ApplyJoin) End() sqltoken.Pos {
+	return a.Factor.End()
+}
+
+func (a *ApplyJoin) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *ApplyJoin) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Node(a.Reference)
+	if a.Outer {
+		sw.Bytes([]byte(" OUTER APPLY "))
+	} else {
+		sw.Bytes([]byte(" CROSS APPLY "))
+	}
+	return sw.Node(a.Factor).End()
+}
+
 //go:generate genmark -t JoinElement -e Node
 
 type TableJoinElement struct {
@@ -716,11 +1400,14 @@ func (j *JoinType) WriteTo(w io.Writer) (int64, error) {
 	return writeSingleBytes(w, []byte(j.ToSQLString()))
 }
 
-// ORDER BY Expr [ASC | DESC]
+// ORDER BY Expr [ASC | DESC | USING Using] [NULLS { FIRST | LAST }]
 type OrderByExpr struct {
 	Expr        Node
 	OrderingPos sqltoken.Pos // ASC / DESC keyword position if ASC != nil
 	ASC         *bool
+	Using       *Operator // USING operator (PostgreSQL)
+	NullsFirst  *bool
+	NullsPos    sqltoken.Pos // last position of NULLS FIRST / NULLS LAST if NullsFirst != nil
 }
 
 func (o *OrderByExpr) Pos() sqltoken.Pos {
@@ -728,6 +1415,14 @@ func (o *OrderByExpr) Pos() sqltoken.Pos {
 }
 
 func (o *OrderByExpr) End() sqltoken.Pos {
+	if o.NullsFirst != nil {
+		return o.NullsPos
+	}
+
+	if o.Using != nil {
+		return o.Using.End()
+	}
+
 	if o.ASC != nil {
 		return o.OrderingPos
 	}
@@ -749,16 +1444,47 @@ func (o *OrderByExpr) WriteTo(w io.Writer) (int64, error) {
 			sw.Bytes([]byte(" DESC"))
 		}
 	}
+	if o.Using != nil {
+		sw.Bytes([]byte(" USING ")).Node(o.Using)
+	}
+	if o.NullsFirst != nil {
+		if *o.NullsFirst {
+			sw.Bytes([]byte(" NULLS FIRST"))
+		} else {
+			sw.Bytes([]byte(" NULLS LAST"))
+		}
+	}
 	return sw.End()
 }
 
+type LimitSyntax int
+
+const (
+	LimitOffsetSyntax LimitSyntax = iota // LIMIT LimitValue [OFFSET OffsetValue]
+	LimitCommaSyntax                     // LIMIT OffsetValue, LimitValue (MySQL)
+	OffsetFetchSyntax                    // [OFFSET OffsetValue [ROW | ROWS]] [FETCH ...]
+	OffsetLimitSyntax                    // OFFSET OffsetValue [ROW | ROWS] LIMIT [ALL | LimitValue] (PostgreSQL)
+)
+
 // LIMIT [ALL | LimitValue ] [ OFFSET OffsetValue]
+// LIMIT OffsetValue, LimitValue
+// [OFFSET OffsetValue [ROW | ROWS]] [FETCH { FIRST | NEXT } [LimitValue] { ROW | ROWS } { ONLY | WITH TIES }]
+// OFFSET OffsetValue [ROW | ROWS] LIMIT [ALL | LimitValue]
+//
+// All syntaxes are normalized into LimitValue and OffsetValue, and Syntax keeps the original one.
+// LimitValue is nil for `FETCH FIRST ROW ONLY` without the count.
 type LimitExpr struct {
 	All         bool
 	AllPos      sqltoken.Pos // ALL keyword position if All is true
-	Limit       sqltoken.Pos // Limit keyword position
-	LimitValue  *LongValue
-	OffsetValue *LongValue
+	Limit       sqltoken.Pos // LIMIT keyword position, or OFFSET / FETCH keyword position of OffsetFetchSyntax
+	LimitValue  Node
+	OffsetValue Node
+	Syntax      LimitSyntax
+	OffsetRows  string       // ROW or ROWS after OFFSET value of OffsetFetchSyntax and OffsetLimitSyntax, if any
+	FetchFirst  string       // FIRST or NEXT if the FETCH clause exists
+	FetchRows   string       // ROW or ROWS of the FETCH clause
+	WithTies    bool         // FETCH ... WITH TIES instead of ONLY
+	To          sqltoken.Pos // last position of OffsetFetchSyntax and OffsetLimitSyntax
 }
 
 func (l *LimitExpr) Pos() sqltoken.Pos {
@@ -766,14 +1492,21 @@ func (l *LimitExpr) Pos() sqltoken.Pos {
 }
 
 func (l *LimitExpr) End() sqltoken.Pos {
-	if l.All {
-		return l.AllPos
+	if l.Syntax == OffsetFetchSyntax || l.Syntax == OffsetLimitSyntax {
+		return l.To
+	}
+
+	if l.Syntax == LimitCommaSyntax {
+		return l.LimitValue.End()
 	}
 
 	if l.OffsetValue != nil {
-		return l.OffsetValue.To
+		return l.OffsetValue.End()
 	}
-	return l.LimitValue.To
+	if l.All {
+		return l.AllPos
+	}
+	return l.LimitValue.End()
 }
 
 func (l *LimitExpr) ToSQLString() string {
@@ -782,6 +1515,46 @@ func (l *LimitExpr) ToSQLString() string {
 
 func (l *LimitExpr) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
+	switch l.Syntax {
+	case LimitCommaSyntax:
+		return sw.Bytes([]byte("LIMIT ")).Node(l.OffsetValue).
+			Bytes([]byte(", ")).Node(l.LimitValue).
+			End()
+	case OffsetFetchSyntax:
+		if l.OffsetValue != nil {
+			sw.Bytes([]byte("OFFSET ")).Node(l.OffsetValue)
+			if l.OffsetRows != "" {
+				sw.Space().Bytes([]byte(l.OffsetRows))
+			}
+		}
+		if l.FetchFirst != "" {
+			if l.OffsetValue != nil {
+				sw.Space()
+			}
+			sw.Bytes([]byte("FETCH " + l.FetchFirst + " "))
+			if l.LimitValue != nil {
+				sw.Node(l.LimitValue).Space()
+			}
+			sw.Bytes([]byte(l.FetchRows))
+			if l.WithTies {
+				sw.Bytes([]byte(" WITH TIES"))
+			} else {
+				sw.Bytes([]byte(" ONLY"))
+			}
+		}
+		return sw.End()
+	case OffsetLimitSyntax:
+		sw.Bytes([]byte("OFFSET ")).Node(l.OffsetValue)
+		if l.OffsetRows != "" {
+			sw.Space().Bytes([]byte(l.OffsetRows))
+		}
+		sw.Bytes([]byte(" LIMIT "))
+		if l.All {
+			return sw.Bytes([]byte("ALL")).End()
+		}
+		return sw.Node(l.LimitValue).End()
+	}
+
 	sw.Bytes([]byte("LIMIT "))
 	if l.All {
 		sw.Bytes([]byte("ALL"))
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/andreyvit/diff"
+
+	"github.com/moomou/xsqlparser/sqltoken"
 )
 
 func TestSQLSelect_ToSQLString(t *testing.T) {
@@ -28,6 +30,108 @@ func TestSQLSelect_ToSQLString(t *testing.T) {
 			},
 			out: "SELECT test FROM test_table",
 		},
+		{
+			name: "distinct",
+			in: &SQLSelect{
+				Distinct: true,
+				Projection: []SQLSelectItem{
+					&UnnamedSelectItem{
+						Node: NewIdent("test"),
+					},
+				},
+			},
+			out: "SELECT DISTINCT test",
+		},
+		{
+			name: "distinct on",
+			in: &SQLSelect{
+				Distinct: true,
+				DistinctClause: &SelectDistinct{
+					Type: SelectDistinctOn,
+					On:   []Node{NewIdent("user_id"), NewIdent("day")},
+				},
+				Projection: []SQLSelectItem{
+					&UnnamedSelectItem{
+						Node: NewIdent("test"),
+					},
+				},
+			},
+			out: "SELECT DISTINCT ON (user_id, day) test",
+		},
+		{
+			name: "distinct on turned off",
+			in: &SQLSelect{
+				Distinct: false,
+				DistinctClause: &SelectDistinct{
+					Type: SelectDistinctOn,
+					On:   []Node{NewIdent("user_id")},
+				},
+				Projection: []SQLSelectItem{
+					&UnnamedSelectItem{
+						Node: NewIdent("test"),
+					},
+				},
+			},
+			out: "SELECT test",
+		},
+		{
+			name: "all",
+			in: &SQLSelect{
+				DistinctClause: &SelectDistinct{
+					Type: SelectAll,
+				},
+				Projection: []SQLSelectItem{
+					&UnnamedSelectItem{
+						Node: NewIdent("test"),
+					},
+				},
+			},
+			out: "SELECT ALL test",
+		},
+		{
+			name: "top",
+			in: &SQLSelect{
+				Top: &Top{
+					LParen:  sqltoken.NewPos(1, 12),
+					Expr:    NewLongValue(10),
+					Percent: true,
+				},
+				Projection: []SQLSelectItem{
+					&UnnamedSelectItem{
+						Node: NewIdent("test"),
+					},
+				},
+			},
+			out: "SELECT TOP (10) PERCENT test",
+		},
+		{
+			name: "top without parentheses",
+			in: &SQLSelect{
+				Top: &Top{
+					Expr: NewLongValue(5),
+				},
+				Projection: []SQLSelectItem{
+					&UnnamedSelectItem{
+						Node: NewIdent("test"),
+					},
+				},
+			},
+			out: "SELECT TOP 5 test",
+		},
+		{
+			name: "top expression",
+			in: &SQLSelect{
+				Top: &Top{
+					Expr: NewIdent("n"),
+				},
+				Projection: []SQLSelectItem{
+					&UnnamedSelectItem{
+						Node: NewIdent("test"),
+					},
+				},
+			},
+			out: "SELECT TOP (n) test",
+		},
 		{
 			name: "join",
 			in: &SQLSelect{
@@ -199,6 +303,8 @@ func TestSQLSelect_ToSQLString(t *testing.T) {
 }
 
 func TestSQLQuery_ToSQLString(t *testing.T) {
+	notMaterialized := false
+
 	cases := []struct {
 		name string
 		in   *QueryStmt
@@ -275,6 +381,37 @@ func TestSQLQuery_ToSQLString(t *testing.T) {
 				"WHERE region IN (SELECT region FROM top_regions) " +
 				"GROUP BY region, product",
 		},
+		{
+			name: "with recursive",
+			in: &QueryStmt{
+				Recursive: true,
+				CTEs: []*CTE{
+					{
+						Alias:        NewIdent("t"),
+						Columns:      []*Ident{NewIdent("n")},
+						Materialized: &notMaterialized,
+						Query: &QueryStmt{
+							Body: &SQLSelect{
+								Projection: []SQLSelectItem{&UnnamedSelectItem{Node: NewIdent("n")}},
+								FromClause: []TableReference{&Table{Name: NewObjectName("s")}},
+							},
+						},
+						Cycle: &CTECycle{
+							Columns: []*Ident{NewIdent("n")},
+							Set:     NewIdent("is_cycle"),
+							Using:   NewIdent("path"),
+						},
+					},
+				},
+				Body: &SQLSelect{
+					Projection: []SQLSelectItem{&UnnamedSelectItem{Node: NewIdent("n")}},
+					FromClause: []TableReference{&Table{Name: NewObjectName("t")}},
+				},
+			},
+			out: "WITH RECURSIVE t (n) AS NOT MATERIALIZED (SELECT n FROM s) " +
+				"CYCLE n SET is_cycle USING path " +
+				"SELECT n FROM t",
+		},
 		{
 			name: "order by and limit",
 			in: &QueryStmt{
@@ -320,6 +457,30 @@ func TestSQLQuery_ToSQLString(t *testing.T) {
 				"WHERE region IN (SELECT region FROM top_regions) " +
 				"ORDER BY product_units LIMIT 100",
 		},
+		{
+			name: "locking clause",
+			in: &QueryStmt{
+				Body: &SQLSelect{
+					Projection: []SQLSelectItem{
+						&UnnamedSelectItem{Node: NewIdent("id")},
+					},
+					FromClause: []TableReference{
+						&Table{
+							Name: NewObjectName("jobs"),
+						},
+					},
+				},
+				Limit: &LimitExpr{LimitValue: NewLongValue(1)},
+				Locks: []*LockingClause{
+					{
+						Strength: ForUpdate,
+						Of:       []*ObjectName{NewObjectName("jobs")},
+						Wait:     LockSkipLocked,
+					},
+				},
+			},
+			out: "SELECT id FROM jobs LIMIT 1 FOR UPDATE OF jobs SKIP LOCKED",
+		},
 		{
 			name: "exists",
 			in: &QueryStmt{
@@ -667,3 +828,48 @@ func BenchmarkSQLQuery_ToSQLString(b *testing.B) {
 	}
 
 }
+
+func TestQueryStmt_IsLocking(t *testing.T) {
+	sel := &SQLSelect{
+		Projection: []SQLSelectItem{
+			&UnnamedSelectItem{Node: NewIdent("id")},
+		},
+	}
+	locked := &QueryStmt{
+		Body:  sel,
+		Locks: []*LockingClause{{Strength: ForShare}},
+	}
+
+	cases := []struct {
+		name string
+		in   *QueryStmt
+		out  bool
+	}{
+		{
+			name: "plain select",
+			in:   &QueryStmt{Body: sel},
+			out:  false,
+		},
+		{
+			name: "locking select",
+			in:   locked,
+			out:  true,
+		},
+		{
+			name: "locking cte",
+			in: &QueryStmt{
+				CTEs: []*CTE{{Alias: NewIdent("c"), Query: locked}},
+				Body: sel,
+			},
+			out: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if act := c.in.IsLocking(); act != c.out {
+				t.Errorf("must be %v but %v", c.out, act)
+			}
+		})
+	}
+}
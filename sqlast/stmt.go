@@ -12,18 +12,39 @@ import (
 // Insert Statement
 type InsertStmt struct {
 	stmt
-	Insert            sqltoken.Pos // first position of INSERT keyword
+	With              sqltoken.Pos // first position of WITH if CTEs is not blank
+	Recursive         bool         // WITH RECURSIVE
+	CTEs              []*CTE
+	Insert            sqltoken.Pos // first position of INSERT or REPLACE keyword
+	Replace           bool         // REPLACE INTO
+	Ignore            bool         // INSERT IGNORE (MySQL)
+	Or                string       // SQLite conflict resolution of INSERT OR ... (REPLACE, IGNORE, ABORT, FAIL or ROLLBACK)
 	TableName         *ObjectName
+	Alias             *Ident // INSERT INTO t AS alias (PostgreSQL and SQLite)
 	Columns           []*Ident
-	Source            InsertSource  // Insert Source [SubQuery or Constructor]
+	Output            *OutputClause // T-SQL only
+	Source            InsertSource  // Insert Source [SubQuery, Constructor, DefaultValues or Set]
 	UpdateAssignments []*Assignment // MySQL only (ON DUPLICATED KEYS)
+	OnConflict        *OnConflict   // PostgreSQL and SQLite
+	Returning         *ReturningClause
 }
 
 func (i *InsertStmt) Pos() sqltoken.Pos {
+	if len(i.CTEs) != 0 {
+		return i.With
+	}
 	return i.Insert
 }
 
 func (i *InsertStmt) End() sqltoken.Pos {
+	if i.Returning != nil {
+		return i.Returning.End()
+	}
+
+	if i.OnConflict != nil {
+		return i.OnConflict.End()
+	}
+
 	if len(i.UpdateAssignments) != 0 {
 		return i.UpdateAssignments[len(i.UpdateAssignments)-1].End()
 	}
@@ -36,11 +57,30 @@ func (i *InsertStmt) ToSQLString() string {
 }
 
 func (i *InsertStmt) WriteTo(w io.Writer) (int64, error) {
-	sw := newSQLWriter(w)
-	sw.Bytes([]byte("INSERT INTO ")).Node(i.TableName).Space()
+	sw := newSQLWriter(w).With(i.Recursive, i.CTEs)
+	if i.Replace {
+		sw.Bytes([]byte("REPLACE INTO "))
+	} else {
+		sw.Bytes([]byte("INSERT "))
+		if i.Ignore {
+			sw.Bytes([]byte("IGNORE "))
+		}
+		if i.Or != "" {
+			sw.Bytes([]byte("OR ")).Bytes([]byte(i.Or)).Space()
+		}
+		sw.Bytes([]byte("INTO "))
+	}
+	sw.Node(i.TableName)
+	if i.Alias != nil {
+		sw.As().Node(i.Alias)
+	}
+	sw.Space()
 	if len(i.Columns) != 0 {
 		sw.LParen().Idents(i.Columns, []byte(", ")).RParen().Space()
 	}
+	if i.Output != nil {
+		sw.Node(i.Output).Space()
+	}
 	sw.Node(i.Source)
 	if len(i.UpdateAssignments) != 0 {
 		sw.Bytes([]byte(" ON DUPLICATE KEY UPDATE "))
@@ -48,6 +88,12 @@ func (i *InsertStmt) WriteTo(w io.Writer) (int64, error) {
 			sw.JoinComma(i, assignment)
 		}
 	}
+	if i.OnConflict != nil {
+		sw.Space().Node(i.OnConflict)
+	}
+	if i.Returning != nil {
+		sw.Space().Node(i.Returning)
+	}
 	return sw.End()
 }
 
@@ -75,8 +121,62 @@ func (s *SubQuerySource) WriteTo(w io.Writer) (int64, error) {
 	return s.SubQuery.WriteTo(w)
 }
 
+// DefaultValuesSource is `DEFAULT VALUES` of INSERT.
+type DefaultValuesSource struct {
+	insertSource
+	Default sqltoken.Pos // first position of DEFAULT keyword
+	Values  sqltoken.Pos // last position of VALUES keyword
+}
+
+func (d *DefaultValuesSource) Pos() sqltoken.Pos {
+	return d.Default
+}
+
+func (d *DefaultValuesSource) End() sqltoken.Pos {
+	return d.Values
+}
+
+func (d *DefaultValuesSource) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DefaultValuesSource) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("DEFAULT VALUES"))
+}
+
+// SetSource is `SET a = 1, b = 2` of MySQL INSERT.
+type SetSource struct {
+	insertSource
+	Set         sqltoken.Pos
+	Assignments []*Assignment
+}
+
+func (s *SetSource) Pos() sqltoken.Pos {
+	return s.Set
+}
+
+func (s *SetSource) End() sqltoken.Pos {
+	return s.Assignments[len(s.Assignments)-1].End()
+}
+
+func (s *SetSource) ToSQLString() string {
+	return toSQLString(s)
+}
+
+func (s *SetSource) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("SET "))
+	for i, assignment := range s.Assignments {
+		sw.JoinComma(i, assignment)
+	}
+	return sw.End()
+}
+
+// ConstructorSource is `VALUES (...), (...)`.
+// It is the source of INSERT and also a query body like SELECT.
 type ConstructorSource struct {
 	insertSource
+	sqlSetExpr
 	Values sqltoken.Pos
 	Rows   []*RowValueExpr
 }
@@ -129,6 +229,27 @@ func (r *RowValueExpr) WriteTo(w io.Writer) (int64, error) {
 	return sw.End()
 }
 
+// DefaultValue is DEFAULT in VALUES rows and SET assignments, which is the default value of the column.
+type DefaultValue struct {
+	From, To sqltoken.Pos
+}
+
+func (d *DefaultValue) Pos() sqltoken.Pos {
+	return d.From
+}
+
+func (d *DefaultValue) End() sqltoken.Pos {
+	return d.To
+}
+
+func (d *DefaultValue) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DefaultValue) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("DEFAULT"))
+}
+
 // TODO Remove CopyStmt
 type CopyStmt struct {
 	stmt
@@ -177,21 +298,37 @@ func (c *CopyStmt) WriteTo(w io.Writer) (int64, error) {
 
 type UpdateStmt struct {
 	stmt
+	With        sqltoken.Pos // first position of WITH if CTEs is not blank
+	Recursive   bool         // WITH RECURSIVE
+	CTEs        []*CTE
 	Update      sqltoken.Pos
 	TableName   *ObjectName
 	Assignments []*Assignment
+	Output      *OutputClause // T-SQL only
 	Selection   Node
+	Returning   *ReturningClause
 }
 
 func (u *UpdateStmt) Pos() sqltoken.Pos {
+	if len(u.CTEs) != 0 {
+		return u.With
+	}
 	return u.Update
 }
 
 func (u *UpdateStmt) End() sqltoken.Pos {
+	if u.Returning != nil {
+		return u.Returning.End()
+	}
+
 	if u.Selection != nil {
 		return u.Selection.End()
 	}
 
+	if u.Output != nil {
+		return u.Output.End()
+	}
+
 	return u.Assignments[len(u.Assignments)-1].End()
 }
 
@@ -200,35 +337,57 @@ func (u *UpdateStmt) ToSQLString() string {
 }
 
 func (u *UpdateStmt) WriteTo(w io.Writer) (int64, error) {
-	sw := newSQLWriter(w)
+	sw := newSQLWriter(w).With(u.Recursive, u.CTEs)
 	sw.Bytes([]byte("UPDATE ")).Node(u.TableName).Bytes([]byte(" SET "))
 	if u.Assignments != nil {
 		for i, assignment := range u.Assignments {
 			sw.JoinComma(i, assignment)
 		}
 	}
+	if u.Output != nil {
+		sw.Space().Node(u.Output)
+	}
 	if u.Selection != nil {
 		sw.Bytes([]byte(" WHERE ")).Node(u.Selection)
 	}
+	if u.Returning != nil {
+		sw.Space().Node(u.Returning)
+	}
 	return sw.End()
 }
 
 type DeleteStmt struct {
 	stmt
+	With      sqltoken.Pos // first position of WITH if CTEs is not blank
+	Recursive bool         // WITH RECURSIVE
+	CTEs      []*CTE
 	Delete    sqltoken.Pos
 	TableName *ObjectName
+	Output    *OutputClause // T-SQL only
 	Selection Node
+	Returning *ReturningClause
 }
 
 func (d *DeleteStmt) Pos() sqltoken.Pos {
+	if len(d.CTEs) != 0 {
+		return d.With
+	}
 	return d.Delete
 }
 
 func (d *DeleteStmt) End() sqltoken.Pos {
+	if d.Returning != nil {
+		return d.Returning.End()
+	}
+
 	if d.Selection != nil {
 		return d.Selection.End()
 	}
 
+	if d.Output != nil {
+		return d.Output.End()
+	}
+
 	return d.TableName.End()
 }
 
@@ -237,11 +396,148 @@ func (d *DeleteStmt) ToSQLString() string {
 }
 
 func (d *DeleteStmt) WriteTo(w io.Writer) (int64, error) {
-	sw := newSQLWriter(w)
+	sw := newSQLWriter(w).With(d.Recursive, d.CTEs)
 	sw.Bytes([]byte("DELETE FROM ")).Node(d.TableName)
+	if d.Output != nil {
+		sw.Space().Node(d.Output)
+	}
 	if d.Selection != nil {
 		sw.Bytes([]byte(" WHERE ")).Node(d.Selection)
 	}
+	if d.Returning != nil {
+		sw.Space().Node(d.Returning)
+	}
+	return sw.End()
+}
+
+// OutputClause is OUTPUT clause of T-SQL INSERT, UPDATE and DELETE.
+//  OUTPUT inserted.*, deleted.id [INTO table [(columns)]]
+type OutputClause struct {
+	Output      sqltoken.Pos
+	Items       []SQLSelectItem
+	Into        *ObjectName
+	IntoColumns []*Ident
+	RParen      sqltoken.Pos // position of the RParen of IntoColumns
+}
+
+func (o *OutputClause) Pos() sqltoken.Pos {
+	return o.Output
+}
+
+func (o *OutputClause) End() sqltoken.Pos {
+	if len(o.IntoColumns) != 0 {
+		return o.RParen
+	}
+	if o.Into != nil {
+		return o.Into.End()
+	}
+	return o.Items[len(o.Items)-1].End()
+}
+
+func (o *OutputClause) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *OutputClause) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("OUTPUT "))
+	for i, item := range o.Items {
+		sw.JoinComma(i, item)
+	}
+	if o.Into != nil {
+		sw.Bytes([]byte(" INTO ")).Node(o.Into)
+		if len(o.IntoColumns) != 0 {
+			sw.Space().LParen().Idents(o.IntoColumns, []byte(", ")).RParen()
+		}
+	}
+	return sw.End()
+}
+
+// ReturningClause is RETURNING clause of PostgreSQL and SQLite INSERT, UPDATE and DELETE.
+//  RETURNING *, id AS new_id
+type ReturningClause struct {
+	Returning sqltoken.Pos
+	Items     []SQLSelectItem
+}
+
+func (r *ReturningClause) Pos() sqltoken.Pos {
+	return r.Returning
+}
+
+func (r *ReturningClause) End() sqltoken.Pos {
+	return r.Items[len(r.Items)-1].End()
+}
+
+func (r *ReturningClause) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *ReturningClause) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("RETURNING "))
+	for i, item := range r.Items {
+		sw.JoinComma(i, item)
+	}
+	return sw.End()
+}
+
+// OnConflict is ON CONFLICT clause of PostgreSQL and SQLite INSERT.
+//  ON CONFLICT [(Target...) [WHERE TargetWhere] | ON CONSTRAINT Constraint]
+//  DO NOTHING | DO UPDATE SET Assignments... [WHERE Selection]
+// The row proposed for insertion is referred as EXCLUDED (e.g. EXCLUDED.name) in Assignments and Selection.
+type OnConflict struct {
+	On           sqltoken.Pos // first position of ON keyword
+	Target       []Node
+	TargetRParen sqltoken.Pos
+	TargetWhere  Node
+	Constraint   *Ident
+	DoNothing    bool
+	Nothing      sqltoken.Pos // last position of NOTHING keyword if DoNothing is true
+	Assignments  []*Assignment
+	Selection    Node
+}
+
+func (o *OnConflict) Pos() sqltoken.Pos {
+	return o.On
+}
+
+func (o *OnConflict) End() sqltoken.Pos {
+	if o.DoNothing {
+		return o.Nothing
+	}
+	if o.Selection != nil {
+		return o.Selection.End()
+	}
+	return o.Assignments[len(o.Assignments)-1].End()
+}
+
+func (o *OnConflict) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *OnConflict) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("ON CONFLICT "))
+	if len(o.Target) != 0 {
+		sw.LParen().Nodes(o.Target).RParen().Space()
+		if o.TargetWhere != nil {
+			sw.Bytes([]byte("WHERE ")).Node(o.TargetWhere).Space()
+		}
+	}
+	if o.Constraint != nil {
+		sw.Bytes([]byte("ON CONSTRAINT ")).Node(o.Constraint).Space()
+	}
+	if o.DoNothing {
+		sw.Bytes([]byte("DO NOTHING"))
+		return sw.End()
+	}
+	sw.Bytes([]byte("DO UPDATE SET "))
+	for i, assignment := range o.Assignments {
+		sw.JoinComma(i, assignment)
+	}
+	if o.Selection != nil {
+		sw.Bytes([]byte(" WHERE ")).Node(o.Selection)
+	}
 	return sw.End()
 }
 
@@ -288,6 +584,9 @@ func (c *CreateTableStmt) Pos() sqltoken.Pos {
 }
 
 func (c *CreateTableStmt) End() sqltoken.Pos {
+	if len(c.Options) != 0 {
+		return c.Options[len(c.Options)-1].End()
+	}
 	return c.Elements[len(c.Elements)-1].End()
 }
 
@@ -305,6 +604,7 @@ func (c *CreateTableStmt) WriteTo(w io.Writer) (int64, error) {
 	}
 	sw.RParen()
 	if len(c.Options) != 0 {
+		sw.Space()
 		for i, option := range c.Options {
 			sw.JoinComma(i, option)
 		}
@@ -312,12 +612,18 @@ func (c *CreateTableStmt) WriteTo(w io.Writer) (int64, error) {
 	return sw.End()
 }
 
+// `ID = Value` or `(Columns) = Value` where ID is nil
 type Assignment struct {
-	ID    *Ident
-	Value Node
+	ID      *Ident
+	Columns []*Ident
+	LParen  sqltoken.Pos // position of ( before Columns
+	Value   Node
 }
 
 func (a *Assignment) Pos() sqltoken.Pos {
+	if a.ID == nil {
+		return a.LParen
+	}
 	return a.ID.Pos()
 }
 
@@ -330,7 +636,13 @@ func (a *Assignment) ToSQLString() string {
 }
 
 func (a *Assignment) WriteTo(w io.Writer) (int64, error) {
-	return newSQLWriter(w).Node(a.ID).Bytes([]byte(" = ")).Node(a.Value).End()
+	sw := newSQLWriter(w)
+	if a.ID == nil {
+		sw.LParen().Idents(a.Columns, []byte(", ")).RParen()
+	} else {
+		sw.Node(a.ID)
+	}
+	return sw.Bytes([]byte(" = ")).Node(a.Value).End()
 }
 
 //go:generate genmark -t TableElement -e Node
@@ -506,11 +818,20 @@ func (c *ColumnDef) WriteTo(w io.Writer) (int64, error) {
 		sw.Bytes([]byte(" DEFAULT ")).Node(c.Default)
 	}
 	for _, m := range c.MyDataTypeDecoration {
+		if a, ok := m.(*AutoIncrement); ok && a.SQLite {
+			continue
+		}
 		sw.Space().Node(m)
 	}
 	for _, cons := range c.Constraints {
 		sw.Node(cons)
 	}
+	// AUTOINCREMENT of SQLite is a part of PRIMARY KEY constraint
+	for _, m := range c.MyDataTypeDecoration {
+		if a, ok := m.(*AutoIncrement); ok && a.SQLite {
+			sw.Space().Node(m)
+		}
+	}
 	return sw.End()
 }
 
@@ -520,13 +841,17 @@ type AutoIncrement struct {
 	myDataTypeDecoration
 	Auto      sqltoken.Pos
 	Increment sqltoken.Pos
+	SQLite    bool // written as AUTOINCREMENT
 }
 
 func (a *AutoIncrement) ToSQLString() string {
-	return "AUTO_INCREMENT"
+	return toSQLString(a)
 }
 
 func (a *AutoIncrement) WriteTo(w io.Writer) (int64, error) {
+	if a.SQLite {
+		return writeSingleBytes(w, []byte("AUTOINCREMENT"))
+	}
 	return writeSingleBytes(w, []byte("AUTO_INCREMENT"))
 }
 
@@ -638,6 +963,7 @@ type ReferencesColumnSpec struct {
 	RParen     sqltoken.Pos
 	TableName  *ObjectName
 	Columns    []*Ident
+	Actions    []*ReferentialAction
 }
 
 func (r *ReferencesColumnSpec) Pos() sqltoken.Pos {
@@ -645,6 +971,9 @@ func (r *ReferencesColumnSpec) Pos() sqltoken.Pos {
 }
 
 func (r *ReferencesColumnSpec) End() sqltoken.Pos {
+	if len(r.Actions) != 0 {
+		return r.Actions[len(r.Actions)-1].End()
+	}
 	return r.RParen
 }
 
@@ -656,9 +985,38 @@ func (r *ReferencesColumnSpec) WriteTo(w io.Writer) (int64, error) {
 	sw := newSQLWriter(w)
 	sw.Bytes([]byte("REFERENCES ")).Node(r.TableName)
 	sw.LParen().Idents(r.Columns, []byte(", ")).RParen()
+	for _, a := range r.Actions {
+		sw.Space().Node(a)
+	}
 	return sw.End()
 }
 
+// ON { DELETE | UPDATE } { CASCADE | RESTRICT | SET NULL | SET DEFAULT | NO ACTION }
+type ReferentialAction struct {
+	On     sqltoken.Pos // first position of ON keyword
+	To     sqltoken.Pos // last position of Action
+	Event  string       // DELETE or UPDATE
+	Action string
+}
+
+func (r *ReferentialAction) Pos() sqltoken.Pos {
+	return r.On
+}
+
+func (r *ReferentialAction) End() sqltoken.Pos {
+	return r.To
+}
+
+func (r *ReferentialAction) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *ReferentialAction) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).
+		Bytes([]byte("ON ")).Bytes([]byte(r.Event)).Space().Bytes([]byte(r.Action)).
+		End()
+}
+
 type CheckColumnSpec struct {
 	Expr   Node
 	Check  sqltoken.Pos
@@ -1178,3 +1536,148 @@ func (e *ExplainStmt) ToSQLString() string {
 func (e *ExplainStmt) WriteTo(w io.Writer) (int64, error) {
 	return newSQLWriter(w).Bytes([]byte("EXPLAIN ")).Node(e.Stmt).End()
 }
+
+// PragmaStmt is a SQLite PRAGMA statement.
+//  PRAGMA name, PRAGMA name = value or PRAGMA name(value)
+type PragmaStmt struct {
+	stmt
+	Pragma sqltoken.Pos
+	Name   *ObjectName
+	Value  Node // nil when the pragma is queried
+	Call   bool // PRAGMA name(value)
+	RParen sqltoken.Pos
+}
+
+func (p *PragmaStmt) Pos() sqltoken.Pos {
+	return p.Pragma
+}
+
+func (p *PragmaStmt) End() sqltoken.Pos {
+	if p.Value == nil {
+		return p.Name.End()
+	}
+	if p.Call {
+		return p.RParen
+	}
+	return p.Value.End()
+}
+
+func (p *PragmaStmt) ToSQLString() string {
+	return toSQLString(p)
+}
+
+func (p *PragmaStmt) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w)
+	sw.Bytes([]byte("PRAGMA ")).Node(p.Name)
+	if p.Value == nil {
+		return sw.End()
+	}
+	if p.Call {
+		sw.LParen().Node(p.Value).RParen()
+	} else {
+		sw.Bytes([]byte(" = ")).Node(p.Value)
+	}
+	return sw.End()
+}
+
+// AttachStmt is a SQLite ATTACH DATABASE statement.
+type AttachStmt struct {
+	stmt
+	Attach   sqltoken.Pos
+	Database bool // DATABASE keyword is present
+	File     Node
+	Schema   *Ident
+}
+
+func (a *AttachStmt) Pos() sqltoken.Pos {
+	return a.Attach
+}
+
+func (a *AttachStmt) End() sqltoken.Pos {
+	return a.Schema.End()
+}
+
+func (a *AttachStmt) ToSQLString() string {
+	return toSQLString(a)
+}
+
+func (a *AttachStmt) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).
+		Bytes([]byte("ATTACH ")).If(a.Database, []byte("DATABASE ")).
+		Node(a.File).Bytes([]byte(" AS ")).Node(a.Schema).
+		End()
+}
+
+// DetachStmt is a SQLite DETACH DATABASE statement.
+type DetachStmt struct {
+	stmt
+	Detach   sqltoken.Pos
+	Database bool // DATABASE keyword is present
+	Schema   *Ident
+}
+
+func (d *DetachStmt) Pos() sqltoken.Pos {
+	return d.Detach
+}
+
+func (d *DetachStmt) End() sqltoken.Pos {
+	return d.Schema.End()
+}
+
+func (d *DetachStmt) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DetachStmt) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).
+		Bytes([]byte("DETACH ")).If(d.Database, []byte("DATABASE ")).Node(d.Schema).
+		End()
+}
+
+// BadStmt is a placeholder for a statement which could not be parsed in error recovery mode.
+// It is written as an empty string.
+type BadStmt struct {
+	stmt
+	From, To sqltoken.Pos
+}
+
+func (b *BadStmt) Pos() sqltoken.Pos {
+	return b.From
+}
+
+func (b *BadStmt) End() sqltoken.Pos {
+	return b.To
+}
+
+func (b *BadStmt) ToSQLString() string {
+	return toSQLString(b)
+}
+
+func (b *BadStmt) WriteTo(w io.Writer) (int64, error) {
+	return 0, nil
+}
+
+// RawStmt is a statement which is not supported by the parser.
+// It keeps the source text and is written as is.
+type RawStmt struct {
+	stmt
+	Keyword  string // leading keyword in upper case e.g. VACUUM, GRANT
+	Text     string // source text without the trailing semicolon
+	From, To sqltoken.Pos
+}
+
+func (r *RawStmt) Pos() sqltoken.Pos {
+	return r.From
+}
+
+func (r *RawStmt) End() sqltoken.Pos {
+	return r.To
+}
+
+func (r *RawStmt) ToSQLString() string {
+	return toSQLString(r)
+}
+
+func (r *RawStmt) WriteTo(w io.Writer) (int64, error) {
+	return newSQLWriter(w).Bytes([]byte(r.Text)).End()
+}
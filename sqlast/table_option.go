@@ -64,3 +64,48 @@ func (m *MyCharset) Pos() sqltoken.Pos {
 func (m *MyCharset) End() sqltoken.Pos {
 	return m.Name.To
 }
+
+// WITHOUT ROWID option of SQLite
+type WithoutRowID struct {
+	tableOption
+	Without sqltoken.Pos
+	RowID   sqltoken.Pos // last position of ROWID keyword
+}
+
+func (o *WithoutRowID) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *WithoutRowID) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("WITHOUT ROWID"))
+}
+
+func (o *WithoutRowID) Pos() sqltoken.Pos {
+	return o.Without
+}
+
+func (o *WithoutRowID) End() sqltoken.Pos {
+	return o.RowID
+}
+
+// STRICT option of SQLite
+type Strict struct {
+	tableOption
+	From, To sqltoken.Pos
+}
+
+func (o *Strict) ToSQLString() string {
+	return toSQLString(o)
+}
+
+func (o *Strict) WriteTo(w io.Writer) (int64, error) {
+	return writeSingleBytes(w, []byte("STRICT"))
+}
+
+func (o *Strict) Pos() sqltoken.Pos {
+	return o.From
+}
+
+func (o *Strict) End() sqltoken.Pos {
+	return o.To
+}
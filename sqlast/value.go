@@ -1,9 +1,9 @@
 package sqlast
 
 import (
-	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/moomou/xsqlparser/sqltoken"
@@ -112,7 +112,7 @@ func (s *SingleQuotedString) WriteTo(w io.Writer) (int64, error) {
 	if err != nil {
 		return int64(n), err
 	}
-	n1, err := io.WriteString(w, s.String)
+	n1, err := io.WriteString(w, strings.ReplaceAll(s.String, "'", "''"))
 	if err != nil {
 		return int64(n + n1), err
 	}
@@ -144,7 +144,7 @@ func (n *NationalStringLiteral) Value() interface{} {
 }
 
 func (n *NationalStringLiteral) ToSQLString() string {
-	return fmt.Sprintf("N'%s'", n.String)
+	return toSQLString(n)
 }
 
 func (n *NationalStringLiteral) WriteTo(w io.Writer) (int64, error) {
@@ -152,7 +152,7 @@ func (n *NationalStringLiteral) WriteTo(w io.Writer) (int64, error) {
 	if err != nil {
 		return int64(n0), err
 	}
-	n1, err := io.WriteString(w, n.String)
+	n1, err := io.WriteString(w, strings.ReplaceAll(n.String, "'", "''"))
 	if err != nil {
 		return int64(n0 + n1), err
 	}
@@ -160,6 +160,128 @@ func (n *NationalStringLiteral) WriteTo(w io.Writer) (int64, error) {
 	return int64(n0 + n1 + n2), err
 }
 
+// EscapedStringLiteral is E'...' string of PostgreSQL.
+// String keeps backslash escapes as written in the source.
+type EscapedStringLiteral struct {
+	From, To sqltoken.Pos
+	Prefix   string // E or e as written, E if empty
+	String   string
+}
+
+func (e *EscapedStringLiteral) Pos() sqltoken.Pos {
+	return e.From
+}
+
+func (e *EscapedStringLiteral) End() sqltoken.Pos {
+	return e.To
+}
+
+func (e *EscapedStringLiteral) Value() interface{} {
+	return e.String
+}
+
+func (e *EscapedStringLiteral) ToSQLString() string {
+	return toSQLString(e)
+}
+
+func (e *EscapedStringLiteral) WriteTo(w io.Writer) (int64, error) {
+	prefix := e.Prefix
+	if prefix == "" {
+		prefix = "E"
+	}
+	return writeSingleString(w, prefix+"'"+e.String+"'")
+}
+
+// BitStringLiteral is B'0101' string.
+type BitStringLiteral struct {
+	From, To sqltoken.Pos
+	Prefix   string // B or b as written, B if empty
+	String   string
+}
+
+func (b *BitStringLiteral) Pos() sqltoken.Pos {
+	return b.From
+}
+
+func (b *BitStringLiteral) End() sqltoken.Pos {
+	return b.To
+}
+
+func (b *BitStringLiteral) Value() interface{} {
+	return b.String
+}
+
+func (b *BitStringLiteral) ToSQLString() string {
+	return toSQLString(b)
+}
+
+func (b *BitStringLiteral) WriteTo(w io.Writer) (int64, error) {
+	prefix := b.Prefix
+	if prefix == "" {
+		prefix = "B"
+	}
+	return writeSingleString(w, prefix+"'"+b.String+"'")
+}
+
+// HexStringLiteral is X'1F' string.
+type HexStringLiteral struct {
+	From, To sqltoken.Pos
+	Prefix   string // X or x as written, X if empty
+	String   string
+}
+
+func (h *HexStringLiteral) Pos() sqltoken.Pos {
+	return h.From
+}
+
+func (h *HexStringLiteral) End() sqltoken.Pos {
+	return h.To
+}
+
+func (h *HexStringLiteral) Value() interface{} {
+	return h.String
+}
+
+func (h *HexStringLiteral) ToSQLString() string {
+	return toSQLString(h)
+}
+
+func (h *HexStringLiteral) WriteTo(w io.Writer) (int64, error) {
+	prefix := h.Prefix
+	if prefix == "" {
+		prefix = "X"
+	}
+	return writeSingleString(w, prefix+"'"+h.String+"'")
+}
+
+// DollarQuotedString is $tag$...$tag$ string of PostgreSQL. Tag is empty for $$...$$.
+type DollarQuotedString struct {
+	From, To sqltoken.Pos
+	Tag      string
+	String   string
+}
+
+func (d *DollarQuotedString) Pos() sqltoken.Pos {
+	return d.From
+}
+
+func (d *DollarQuotedString) End() sqltoken.Pos {
+	return d.To
+}
+
+func (d *DollarQuotedString) Value() interface{} {
+	return d.String
+}
+
+func (d *DollarQuotedString) ToSQLString() string {
+	return toSQLString(d)
+}
+
+func (d *DollarQuotedString) WriteTo(w io.Writer) (int64, error) {
+	quote := "$" + d.Tag + "$"
+	return writeSingleString(w, quote+d.String+quote)
+}
+
 type BooleanValue struct {
 	From, To sqltoken.Pos
 	Boolean  bool
@@ -195,9 +317,12 @@ func (b *BooleanValue) WriteTo(w io.Writer) (int64, error) {
 	}
 }
 
+// `DATE '2006-01-02'`
+// Raw is the original string of the literal and Date is zero if Raw is not in ISO format.
 type DateValue struct {
 	From, To sqltoken.Pos
 	Date     time.Time
+	Raw      string
 }
 
 func (d *DateValue) Pos() sqltoken.Pos {
@@ -217,15 +342,17 @@ func (d *DateValue) ToSQLString() string {
 }
 
 func (d *DateValue) WriteTo(w io.Writer) (int64, error) {
-	var b [16]byte
-	buf := d.Date.AppendFormat(b[:0], "2006-01-02")
-	n, err := w.Write(buf)
-	return int64(n), err
+	return writeTypedLiteral(w, "DATE", d.Raw, d.Date, "2006-01-02")
 }
 
+// `TIME [WITH TIME ZONE | WITHOUT TIME ZONE] '15:04:05'`
+// Raw is the original string of the literal and Time is zero if Raw is not in ISO format.
 type TimeValue struct {
-	From, To sqltoken.Pos
-	Time     time.Time
+	From, To        sqltoken.Pos
+	Time            time.Time
+	WithTimeZone    bool
+	WithoutTimeZone bool // WITHOUT TIME ZONE is written explicitly
+	Raw             string
 }
 
 func NewTimeValue(t time.Time) *TimeValue {
@@ -251,15 +378,22 @@ func (t *TimeValue) ToSQLString() string {
 }
 
 func (t *TimeValue) WriteTo(w io.Writer) (int64, error) {
-	var b [16]byte
-	buf := t.Time.AppendFormat(b[:0], "15:04:05")
-	n, err := w.Write(buf)
-	return int64(n), err
+	if t.WithTimeZone {
+		return writeTypedLiteral(w, "TIME WITH TIME ZONE", t.Raw, t.Time, "15:04:05Z07:00")
+	}
+	if t.WithoutTimeZone {
+		return writeTypedLiteral(w, "TIME WITHOUT TIME ZONE", t.Raw, t.Time, "15:04:05")
+	}
+	return writeTypedLiteral(w, "TIME", t.Raw, t.Time, "15:04:05")
 }
 
+// `TIMESTAMP [WITHOUT TIME ZONE] '2006-01-02 15:04:05'`
+// Raw is the original string of the literal and DateTime is zero if Raw is not in ISO format.
 type DateTimeValue struct {
-	From, To sqltoken.Pos
-	DateTime time.Time
+	From, To        sqltoken.Pos
+	DateTime        time.Time
+	WithoutTimeZone bool // WITHOUT TIME ZONE is written explicitly
+	Raw             string
 }
 
 func NewDateTimeValue(t time.Time) *DateTimeValue {
@@ -281,19 +415,22 @@ func (d *DateTimeValue) Value() interface{} {
 }
 
 func (d *DateTimeValue) ToSQLString() string {
-	return d.DateTime.Format("2006-01-02 15:04:05")
+	return toSQLString(d)
 }
 
 func (d *DateTimeValue) WriteTo(w io.Writer) (int64, error) {
-	var b [32]byte
-	buf := d.DateTime.AppendFormat(b[:0], "2006-01-02 15:04:05")
-	n, err := w.Write(buf)
-	return int64(n), err
+	if d.WithoutTimeZone {
+		return writeTypedLiteral(w, "TIMESTAMP WITHOUT TIME ZONE", d.Raw, d.DateTime, "2006-01-02 15:04:05")
+	}
+	return writeTypedLiteral(w, "TIMESTAMP", d.Raw, d.DateTime, "2006-01-02 15:04:05")
 }
 
+// `TIMESTAMP WITH TIME ZONE '2006-01-02 15:04:05+09'`
+// Raw is the original string of the literal and Timestamp is zero if Raw is not in ISO format.
 type TimestampValue struct {
 	From, To  sqltoken.Pos
 	Timestamp time.Time
+	Raw       string
 }
 
 func NewTimestampValue(t time.Time) *TimestampValue {
@@ -317,10 +454,65 @@ func (t *TimestampValue) ToSQLString() string {
 }
 
 func (t *TimestampValue) WriteTo(w io.Writer) (int64, error) {
-	var b [32]byte
-	buf := t.Timestamp.AppendFormat(b[:0], "2006-01-02 15:04:05")
-	n, err := w.Write(buf)
-	return int64(n), err
+	return writeTypedLiteral(w, "TIMESTAMP WITH TIME ZONE", t.Raw, t.Timestamp, "2006-01-02 15:04:05Z07:00")
+}
+
+// writeTypedLiteral writes `typeName 'raw'`, or tm in layout if raw is empty.
+func writeTypedLiteral(w io.Writer, typeName, raw string, tm time.Time, layout string) (int64, error) {
+	if raw == "" {
+		raw = tm.Format(layout)
+	}
+	return writeSingleString(w, typeName+" '"+strings.ReplaceAll(raw, "'", "''")+"'")
+}
+
+// `INTERVAL Value [LeadingField[(LeadingPrecision)] [TO TrailingField[(FractionalPrecision)]]]`
+// e.g. INTERVAL '1 day', INTERVAL '1-2' YEAR TO MONTH, INTERVAL 3 HOUR (MySQL).
+// For a single SECOND field FractionalPrecision is the second argument of SECOND(p, s).
+type IntervalValue struct {
+	Interval            sqltoken.Pos // first position of INTERVAL keyword
+	Value               Node
+	LeadingField        string
+	LeadingPrecision    *uint
+	TrailingField       string
+	FractionalPrecision *uint
+	To                  sqltoken.Pos
+}
+
+func (i *IntervalValue) Pos() sqltoken.Pos {
+	return i.Interval
+}
+
+func (i *IntervalValue) End() sqltoken.Pos {
+	return i.To
+}
+
+func (i *IntervalValue) ToSQLString() string {
+	return toSQLString(i)
+}
+
+func (i *IntervalValue) WriteTo(w io.Writer) (int64, error) {
+	sw := newSQLWriter(w).Bytes([]byte("INTERVAL ")).Node(i.Value)
+	if i.LeadingField == "" {
+		return sw.End()
+	}
+
+	sw.Space().Bytes([]byte(i.LeadingField))
+	if i.TrailingField == "" {
+		if i.LeadingPrecision != nil {
+			sw.LParen().Int(int(*i.LeadingPrecision))
+			if i.FractionalPrecision != nil {
+				sw.Bytes([]byte(", ")).Int(int(*i.FractionalPrecision))
+			}
+			sw.RParen()
+		}
+		return sw.End()
+	}
+
+	if i.LeadingPrecision != nil {
+		sw.LParen().Int(int(*i.LeadingPrecision)).RParen()
+	}
+	sw.Bytes([]byte(" TO ")).TypeWithOptionalLength([]byte(i.TrailingField), i.FractionalPrecision)
+	return sw.End()
 }
 
 type NullValue struct {
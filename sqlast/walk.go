@@ -42,6 +42,16 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.X)
 	case *IsNotNull:
 		Walk(v, n.X)
+	case *IsDistinctFrom:
+		Walk(v, n.X)
+		Walk(v, n.Y)
+	case *IsBool:
+		Walk(v, n.X)
+	case *PatternEscape:
+		Walk(v, n.Match)
+		Walk(v, n.Escape)
+	case *IntervalValue:
+		Walk(v, n.Value)
 	case *InList:
 		Walk(v, n.Expr)
 		walkASTNodeLists(v, n.List)
@@ -59,17 +69,61 @@ func Walk(v Visitor, node Node) {
 	case *Cast:
 		Walk(v, n.Expr)
 		Walk(v, n.DataType)
+	case *Extract:
+		Walk(v, n.Source)
+	case *Substring:
+		Walk(v, n.Expr)
+		if n.Start != nil {
+			Walk(v, n.Start)
+		}
+		if n.Length != nil {
+			Walk(v, n.Length)
+		}
+	case *Trim:
+		if n.Chars != nil {
+			Walk(v, n.Chars)
+		}
+		Walk(v, n.Expr)
+	case *Position:
+		Walk(v, n.Substr)
+		Walk(v, n.Expr)
+	case *Overlay:
+		Walk(v, n.Expr)
+		Walk(v, n.Placing)
+		Walk(v, n.Start)
+		if n.Length != nil {
+			Walk(v, n.Length)
+		}
 	case *Nested:
 		Walk(v, n.AST)
 	case *UnaryExpr:
 		Walk(v, n.Op)
 		Walk(v, n.Expr)
+	case *Grouping:
+		walkASTNodeLists(v, n.Args)
+	case *ArrayConstructor:
+		walkASTNodeLists(v, n.Elems)
 	case *Function:
 		Walk(v, n.Name)
 		walkASTNodeLists(v, n.Args)
+		for _, o := range n.OrderBy {
+			Walk(v, o)
+		}
+		if n.Separator != nil {
+			Walk(v, n.Separator)
+		}
+		for _, o := range n.WithinGroup {
+			Walk(v, o)
+		}
+		if n.Filter != nil {
+			Walk(v, n.Filter)
+		}
 		if n.Over != nil {
 			Walk(v, n.Over)
 		}
+		if n.OverName != nil {
+			Walk(v, n.OverName)
+		}
 	case *CaseExpr:
 		Walk(v, n.Operand)
 	case *Exists:
@@ -79,6 +133,9 @@ func Walk(v Visitor, node Node) {
 	case *ObjectName:
 		walkIdentLists(v, n.Idents)
 	case *WindowSpec:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
 		walkASTNodeLists(v, n.PartitionBy)
 		for _, o := range n.OrderBy {
 			Walk(v, o)
@@ -92,8 +149,13 @@ func Walk(v Visitor, node Node) {
 		if n.EndBound != nil {
 			Walk(v, n.EndBound)
 		}
+		if n.Exclude != nil {
+			Walk(v, n.Exclude)
+		}
 	case *WindowFrameUnit:
 		// nothing to do
+	case *WindowFrameExclude:
+		// nothing to do
 	case *CurrentRow:
 		// nothing to do
 	case *UnboundedPreceding:
@@ -101,9 +163,9 @@ func Walk(v Visitor, node Node) {
 	case *UnboundedFollowing:
 		// nothing to do
 	case *Preceding:
-		// nothing to do
+		Walk(v, n.Bound)
 	case *Following:
-		// nothing to do
+		Walk(v, n.Bound)
 	case *QueryStmt:
 		for _, c := range n.CTEs {
 			Walk(v, c)
@@ -115,9 +177,38 @@ func Walk(v Visitor, node Node) {
 		if n.Limit != nil {
 			Walk(v, n.Limit)
 		}
+		for _, l := range n.Locks {
+			Walk(v, l)
+		}
+	case *LockingClause:
+		for _, o := range n.Of {
+			Walk(v, o)
+		}
 	case *CTE:
-		Walk(v, n.Query)
+		if n.DML != nil {
+			Walk(v, n.DML)
+		} else {
+			Walk(v, n.Query)
+		}
 		Walk(v, n.Alias)
+		walkIdentLists(v, n.Columns)
+		if n.Search != nil {
+			Walk(v, n.Search)
+		}
+		if n.Cycle != nil {
+			Walk(v, n.Cycle)
+		}
+	case *CTESearch:
+		walkIdentLists(v, n.Columns)
+		Walk(v, n.Set)
+	case *CTECycle:
+		walkIdentLists(v, n.Columns)
+		Walk(v, n.Set)
+		if n.To != nil {
+			Walk(v, n.To)
+			Walk(v, n.Default)
+		}
+		Walk(v, n.Using)
 	case *SelectExpr:
 		Walk(v, n.Select)
 	case *QueryExpr:
@@ -133,6 +224,12 @@ func Walk(v Visitor, node Node) {
 	case *IntersectOperator:
 		// nothing to do
 	case *SQLSelect:
+		if n.DistinctClause != nil {
+			Walk(v, n.DistinctClause)
+		}
+		if n.Top != nil {
+			Walk(v, n.Top)
+		}
 		for _, p := range n.Projection {
 			Walk(v, p)
 		}
@@ -148,6 +245,22 @@ func Walk(v Visitor, node Node) {
 		if n.HavingClause != nil {
 			Walk(v, n.HavingClause)
 		}
+		for _, w := range n.WindowClause {
+			Walk(v, w)
+		}
+	case *SelectDistinct:
+		walkASTNodeLists(v, n.On)
+	case *NamedWindow:
+		Walk(v, n.Name)
+		Walk(v, n.Spec)
+	case *Rollup:
+		walkASTNodeLists(v, n.Elements)
+	case *Cube:
+		walkASTNodeLists(v, n.Elements)
+	case *GroupingSets:
+		walkASTNodeLists(v, n.Sets)
+	case *GroupingSet:
+		walkASTNodeLists(v, n.Exprs)
 	case *QualifiedJoin:
 		Walk(v, n.LeftElement)
 		Walk(v, n.Type)
@@ -168,18 +281,62 @@ func Walk(v Visitor, node Node) {
 	case *CrossJoin:
 		Walk(v, n.Factor)
 		Walk(v, n.Reference)
+	case *ApplyJoin:
+		Walk(v, n.Reference)
+		Walk(v, n.Factor)
+	case *Top:
+		Walk(v, n.Expr)
 	case *Table:
 		Walk(v, n.Name)
 		if n.Alias != nil {
 			Walk(v, n.Alias)
 		}
-		walkASTNodeLists(v, n.Args)
+		walkIdentLists(v, n.Columns)
+		if n.SystemTime != nil {
+			Walk(v, n.SystemTime)
+		}
+		if n.Sample != nil {
+			Walk(v, n.Sample)
+		}
 		walkASTNodeLists(v, n.WithHints)
 	case *Derived:
 		Walk(v, n.SubQuery)
 		if n.Alias != nil {
 			Walk(v, n.Alias)
 		}
+		walkIdentLists(v, n.Columns)
+	case *TableFunction:
+		for _, f := range n.Functions {
+			Walk(v, f)
+		}
+		if n.Alias != nil {
+			Walk(v, n.Alias)
+		}
+		walkIdentLists(v, n.Columns)
+		for _, c := range n.ColumnDefs {
+			Walk(v, c)
+		}
+	case *TableFunctionColumn:
+		Walk(v, n.Name)
+		Walk(v, n.DataType)
+	case *TableSample:
+		if n.Method != nil {
+			Walk(v, n.Method)
+		}
+		walkASTNodeLists(v, n.Args)
+		if n.Seed != nil {
+			Walk(v, n.Seed)
+		}
+	case *SystemTime:
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+		if n.Start != nil {
+			Walk(v, n.Start)
+		}
+		if n.Stop != nil {
+			Walk(v, n.Stop)
+		}
 	case *UnnamedSelectItem:
 		Walk(v, n.Node)
 	case *AliasSelectItem:
@@ -191,8 +348,11 @@ func Walk(v Visitor, node Node) {
 		// nothing to do
 	case *OrderByExpr:
 		Walk(v, n.Expr)
+		if n.Using != nil {
+			Walk(v, n.Using)
+		}
 	case *LimitExpr:
-		if !n.All {
+		if n.LimitValue != nil {
 			Walk(v, n.LimitValue)
 		}
 		if n.OffsetValue != nil {
@@ -245,14 +405,35 @@ func Walk(v Visitor, node Node) {
 	case *Custom:
 		// nothing to do
 	case *InsertStmt:
+		for _, c := range n.CTEs {
+			Walk(v, c)
+		}
 		Walk(v, n.TableName)
+		if n.Alias != nil {
+			Walk(v, n.Alias)
+		}
 		walkIdentLists(v, n.Columns)
+		if n.Output != nil {
+			Walk(v, n.Output)
+		}
 		Walk(v, n.Source)
 
 		for _, a := range n.UpdateAssignments {
 			Walk(v, a)
 		}
+		if n.OnConflict != nil {
+			Walk(v, n.OnConflict)
+		}
+		if n.Returning != nil {
+			Walk(v, n.Returning)
+		}
 
+	case *DefaultValuesSource:
+		// nothing to do
+	case *SetSource:
+		for _, a := range n.Assignments {
+			Walk(v, a)
+		}
 	case *ConstructorSource:
 		for _, r := range n.Rows {
 			Walk(v, r)
@@ -267,13 +448,59 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.TableName)
 		walkIdentLists(v, n.Columns)
 	case *UpdateStmt:
+		for _, c := range n.CTEs {
+			Walk(v, c)
+		}
 		Walk(v, n.TableName)
 		for _, a := range n.Assignments {
 			Walk(v, a)
 		}
-		Walk(v, n.Selection)
+		if n.Output != nil {
+			Walk(v, n.Output)
+		}
+		if n.Selection != nil {
+			Walk(v, n.Selection)
+		}
+		if n.Returning != nil {
+			Walk(v, n.Returning)
+		}
 	case *DeleteStmt:
+		for _, c := range n.CTEs {
+			Walk(v, c)
+		}
 		Walk(v, n.TableName)
+		if n.Output != nil {
+			Walk(v, n.Output)
+		}
+		if n.Selection != nil {
+			Walk(v, n.Selection)
+		}
+		if n.Returning != nil {
+			Walk(v, n.Returning)
+		}
+	case *OutputClause:
+		for _, i := range n.Items {
+			Walk(v, i)
+		}
+		if n.Into != nil {
+			Walk(v, n.Into)
+		}
+		walkIdentLists(v, n.IntoColumns)
+	case *ReturningClause:
+		for _, i := range n.Items {
+			Walk(v, i)
+		}
+	case *OnConflict:
+		walkASTNodeLists(v, n.Target)
+		if n.TargetWhere != nil {
+			Walk(v, n.TargetWhere)
+		}
+		if n.Constraint != nil {
+			Walk(v, n.Constraint)
+		}
+		for _, a := range n.Assignments {
+			Walk(v, a)
+		}
 		if n.Selection != nil {
 			Walk(v, n.Selection)
 		}
@@ -286,7 +513,10 @@ func Walk(v Visitor, node Node) {
 			Walk(v, e)
 		}
 	case *Assignment:
-		Walk(v, n.ID)
+		if n.ID != nil {
+			Walk(v, n.ID)
+		}
+		walkIdentLists(v, n.Columns)
 		Walk(v, n.Value)
 	case *TableConstraint:
 		if n.Name != nil {
@@ -324,6 +554,11 @@ func Walk(v Visitor, node Node) {
 	case *ReferencesColumnSpec:
 		Walk(v, n.TableName)
 		walkIdentLists(v, n.Columns)
+		for _, a := range n.Actions {
+			Walk(v, a)
+		}
+	case *ReferentialAction:
+		// nothing to do
 	case *CheckColumnSpec:
 		Walk(v, n.Expr)
 	case *AlterTableStmt:
@@ -370,13 +605,38 @@ func Walk(v Visitor, node Node) {
 		walkIdentLists(v, n.IndexNames)
 	case *ExplainStmt:
 		Walk(v, n.Stmt)
+	case *CreateVirtualTableStmt:
+		Walk(v, n.Name)
+		Walk(v, n.Using)
+	case *PragmaStmt:
+		Walk(v, n.Name)
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *AttachStmt:
+		Walk(v, n.File)
+		Walk(v, n.Schema)
+	case *DetachStmt:
+		Walk(v, n.Schema)
+	case *BadStmt:
+		// nothing to do
+	case *RawStmt:
+		// nothing to do
 	case *Operator:
 		// nothing to do
+	case *Placeholder:
+		// nothing to do
+	case *DefaultValue:
+		// nothing to do
 	case *NullValue,
 		*LongValue,
 		*DoubleValue,
 		*SingleQuotedString,
 		*NationalStringLiteral,
+		*EscapedStringLiteral,
+		*BitStringLiteral,
+		*HexStringLiteral,
+		*DollarQuotedString,
 		*BooleanValue,
 		*DateValue,
 		*TimeValue,
@@ -146,6 +146,18 @@ func (w *sqlWriter) If(ok bool, b []byte) *sqlWriter {
 	return w
 }
 
+// With writes `WITH [RECURSIVE] ctes... ` with the trailing space if ctes is not empty.
+func (w *sqlWriter) With(recursive bool, ctes []*CTE) *sqlWriter {
+	if len(ctes) == 0 {
+		return w
+	}
+	w.Bytes([]byte("WITH ")).If(recursive, []byte("RECURSIVE "))
+	for i, cte := range ctes {
+		w.JoinComma(i, cte)
+	}
+	return w.Space()
+}
+
 func (w *sqlWriter) As() *sqlWriter {
 	return w.Bytes([]byte(" AS "))
 }
@@ -140,6 +140,16 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		a.apply(n, "X", nil, n.X)
 	case *sqlast.IsNotNull:
 		a.apply(n, "X", nil, n.X)
+	case *sqlast.IsDistinctFrom:
+		a.apply(n, "X", nil, n.X)
+		a.apply(n, "Y", nil, n.Y)
+	case *sqlast.IsBool:
+		a.apply(n, "X", nil, n.X)
+	case *sqlast.PatternEscape:
+		a.apply(n, "Match", nil, n.Match)
+		a.apply(n, "Escape", nil, n.Escape)
+	case *sqlast.IntervalValue:
+		a.apply(n, "Value", nil, n.Value)
 	case *sqlast.InList:
 		a.apply(n, "Expr", nil, n.Expr)
 		a.applyList(n, "List")
@@ -157,17 +167,57 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.Cast:
 		a.apply(n, "Expr", nil, n.Expr)
 		a.apply(n, "DataType", nil, n.DataType)
+	case *sqlast.Extract:
+		a.apply(n, "Source", nil, n.Source)
+	case *sqlast.Substring:
+		a.apply(n, "Expr", nil, n.Expr)
+		if n.Start != nil {
+			a.apply(n, "Start", nil, n.Start)
+		}
+		if n.Length != nil {
+			a.apply(n, "Length", nil, n.Length)
+		}
+	case *sqlast.Trim:
+		if n.Chars != nil {
+			a.apply(n, "Chars", nil, n.Chars)
+		}
+		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.Position:
+		a.apply(n, "Substr", nil, n.Substr)
+		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.Overlay:
+		a.apply(n, "Expr", nil, n.Expr)
+		a.apply(n, "Placing", nil, n.Placing)
+		a.apply(n, "Start", nil, n.Start)
+		if n.Length != nil {
+			a.apply(n, "Length", nil, n.Length)
+		}
 	case *sqlast.Nested:
 		a.apply(n, "AST", nil, n.AST)
 	case *sqlast.UnaryExpr:
 		a.apply(n, "Op", nil, n.Op)
 		a.apply(n, "Expr", nil, n.Expr)
+	case *sqlast.Grouping:
+		a.applyList(n, "Args")
+	case *sqlast.ArrayConstructor:
+		a.applyList(n, "Elems")
 	case *sqlast.Function:
 		a.apply(n, "Name", nil, n.Name)
 		a.applyList(n, "Args")
+		a.applyList(n, "OrderBy")
+		if n.Separator != nil {
+			a.apply(n, "Separator", nil, n.Separator)
+		}
+		a.applyList(n, "WithinGroup")
+		if n.Filter != nil {
+			a.apply(n, "Filter", nil, n.Filter)
+		}
 		if n.Over != nil {
 			a.apply(n, "Over", nil, n.Over)
 		}
+		if n.OverName != nil {
+			a.apply(n, "OverName", nil, n.OverName)
+		}
 	case *sqlast.CaseExpr:
 		a.apply(n, "Operand", nil, n.Operand)
 	case *sqlast.Exists:
@@ -177,6 +227,9 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.ObjectName:
 		a.applyList(n, "Idents")
 	case *sqlast.WindowSpec:
+		if n.Name != nil {
+			a.apply(n, "Name", nil, n.Name)
+		}
 		a.applyList(n, "PartitionBy")
 		a.applyList(n, "OrderBy")
 		if n.WindowsFrame != nil {
@@ -188,13 +241,19 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		if n.EndBound != nil {
 			a.apply(n, "EndBound", nil, n.EndBound)
 		}
+		if n.Exclude != nil {
+			a.apply(n, "Exclude", nil, n.Exclude)
+		}
 	case *sqlast.WindowFrameUnit,
+		*sqlast.WindowFrameExclude,
 		*sqlast.CurrentRow,
 		*sqlast.UnboundedPreceding,
-		*sqlast.UnboundedFollowing,
-		*sqlast.Preceding,
-		*sqlast.Following:
+		*sqlast.UnboundedFollowing:
 		// nothing to do
+	case *sqlast.Preceding:
+		a.apply(n, "Bound", nil, n.Bound)
+	case *sqlast.Following:
+		a.apply(n, "Bound", nil, n.Bound)
 	case *sqlast.QueryStmt:
 		a.applyList(n, "CTEs")
 		a.apply(n, "Body", nil, n.Body)
@@ -202,9 +261,34 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		if n.Limit != nil {
 			a.apply(n, "Limit", nil, n.Limit)
 		}
+		a.applyList(n, "Locks")
+	case *sqlast.LockingClause:
+		a.applyList(n, "Of")
 	case *sqlast.CTE:
-		a.apply(n, "QueryStmt", nil, n.Query)
+		if n.DML != nil {
+			a.apply(n, "DML", nil, n.DML)
+		} else {
+			a.apply(n, "Query", nil, n.Query)
+		}
 		a.apply(n, "Alias", nil, n.Alias)
+		a.applyList(n, "Columns")
+		if n.Search != nil {
+			a.apply(n, "Search", nil, n.Search)
+		}
+		if n.Cycle != nil {
+			a.apply(n, "Cycle", nil, n.Cycle)
+		}
+	case *sqlast.CTESearch:
+		a.applyList(n, "Columns")
+		a.apply(n, "Set", nil, n.Set)
+	case *sqlast.CTECycle:
+		a.applyList(n, "Columns")
+		a.apply(n, "Set", nil, n.Set)
+		if n.To != nil {
+			a.apply(n, "To", nil, n.To)
+			a.apply(n, "Default", nil, n.Default)
+		}
+		a.apply(n, "Using", nil, n.Using)
 	case *sqlast.SelectExpr:
 		a.apply(n, "Select", nil, n.Select)
 	case *sqlast.QueryExpr:
@@ -220,6 +304,12 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.IntersectOperator:
 		// nothing to do
 	case *sqlast.SQLSelect:
+		if n.DistinctClause != nil {
+			a.apply(n, "DistinctClause", nil, n.DistinctClause)
+		}
+		if n.Top != nil {
+			a.apply(n, "Top", nil, n.Top)
+		}
 		a.applyList(n, "Projection")
 		a.applyList(n, "FromClause")
 		if n.WhereClause != nil {
@@ -229,6 +319,20 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		if n.HavingClause != nil {
 			a.apply(n, "HavingClause", nil, n.HavingClause)
 		}
+		a.applyList(n, "WindowClause")
+	case *sqlast.SelectDistinct:
+		a.applyList(n, "On")
+	case *sqlast.NamedWindow:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Spec", nil, n.Spec)
+	case *sqlast.Rollup:
+		a.applyList(n, "Elements")
+	case *sqlast.Cube:
+		a.applyList(n, "Elements")
+	case *sqlast.GroupingSets:
+		a.applyList(n, "Sets")
+	case *sqlast.GroupingSet:
+		a.applyList(n, "Exprs")
 	case *sqlast.QualifiedJoin:
 		a.apply(n, "LeftElement", nil, n.LeftElement)
 		a.apply(n, "Type", nil, n.Type)
@@ -247,18 +351,58 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.CrossJoin:
 		a.apply(n, "Factor", nil, n.Factor)
 		a.apply(n, "Reference", nil, n.Reference)
+	case *sqlast.ApplyJoin:
+		a.apply(n, "Reference", nil, n.Reference)
+		a.apply(n, "Factor", nil, n.Factor)
+	case *sqlast.Top:
+		a.apply(n, "Expr", nil, n.Expr)
 	case *sqlast.Table:
 		a.apply(n, "Name", nil, n.Name)
 		if n.Alias != nil {
 			a.apply(n, "Alias", nil, n.Alias)
 		}
-		a.applyList(n, "Args")
+		a.applyList(n, "Columns")
+		if n.SystemTime != nil {
+			a.apply(n, "SystemTime", nil, n.SystemTime)
+		}
+		if n.Sample != nil {
+			a.apply(n, "Sample", nil, n.Sample)
+		}
 		a.applyList(n, "WithHints")
 	case *sqlast.Derived:
 		a.apply(n, "SubQuery", nil, n.SubQuery)
 		if n.Alias != nil {
 			a.apply(n, "Alias", nil, n.Alias)
 		}
+		a.applyList(n, "Columns")
+	case *sqlast.TableFunction:
+		a.applyList(n, "Functions")
+		if n.Alias != nil {
+			a.apply(n, "Alias", nil, n.Alias)
+		}
+		a.applyList(n, "Columns")
+		a.applyList(n, "ColumnDefs")
+	case *sqlast.TableFunctionColumn:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "DataType", nil, n.DataType)
+	case *sqlast.TableSample:
+		if n.Method != nil {
+			a.apply(n, "Method", nil, n.Method)
+		}
+		a.applyList(n, "Args")
+		if n.Seed != nil {
+			a.apply(n, "Seed", nil, n.Seed)
+		}
+	case *sqlast.SystemTime:
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
+		if n.Start != nil {
+			a.apply(n, "Start", nil, n.Start)
+		}
+		if n.Stop != nil {
+			a.apply(n, "Stop", nil, n.Stop)
+		}
 	case *sqlast.UnnamedSelectItem:
 		a.apply(n, "Node", nil, n.Node)
 	case *sqlast.AliasSelectItem:
@@ -270,8 +414,11 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		// nothing to do
 	case *sqlast.OrderByExpr:
 		a.apply(n, "Expr", nil, n.Expr)
+		if n.Using != nil {
+			a.apply(n, "Using", nil, n.Using)
+		}
 	case *sqlast.LimitExpr:
-		if !n.All {
+		if n.LimitValue != nil {
 			a.apply(n, "LimitValue", nil, n.LimitValue)
 		}
 		if n.OffsetValue != nil {
@@ -324,10 +471,27 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.Custom:
 		// nothing to do
 	case *sqlast.InsertStmt:
+		a.applyList(n, "CTEs")
 		a.apply(n, "TableName", nil, n.TableName)
+		if n.Alias != nil {
+			a.apply(n, "Alias", nil, n.Alias)
+		}
 		a.applyList(n, "Columns")
+		if n.Output != nil {
+			a.apply(n, "Output", nil, n.Output)
+		}
 		a.apply(n, "Source", nil, n.Source)
 		a.applyList(n, "UpdateAssignments")
+		if n.OnConflict != nil {
+			a.apply(n, "OnConflict", nil, n.OnConflict)
+		}
+		if n.Returning != nil {
+			a.apply(n, "Returning", nil, n.Returning)
+		}
+	case *sqlast.DefaultValuesSource:
+		// nothing to do
+	case *sqlast.SetSource:
+		a.applyList(n, "Assignments")
 	case *sqlast.ConstructorSource:
 		a.applyList(n, "Rows")
 	case *sqlast.RowValueExpr:
@@ -338,11 +502,47 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		a.apply(n, "TableName", nil, n.TableName)
 		a.applyList(n, "Columns")
 	case *sqlast.UpdateStmt:
+		a.applyList(n, "CTEs")
 		a.apply(n, "TableName", nil, n.TableName)
 		a.applyList(n, "Assignments")
-		a.apply(n, "Selection", nil, n.Selection)
+		if n.Output != nil {
+			a.apply(n, "Output", nil, n.Output)
+		}
+		if n.Selection != nil {
+			a.apply(n, "Selection", nil, n.Selection)
+		}
+		if n.Returning != nil {
+			a.apply(n, "Returning", nil, n.Returning)
+		}
 	case *sqlast.DeleteStmt:
+		a.applyList(n, "CTEs")
 		a.apply(n, "TableName", nil, n.TableName)
+		if n.Output != nil {
+			a.apply(n, "Output", nil, n.Output)
+		}
+		if n.Selection != nil {
+			a.apply(n, "Selection", nil, n.Selection)
+		}
+		if n.Returning != nil {
+			a.apply(n, "Returning", nil, n.Returning)
+		}
+	case *sqlast.OutputClause:
+		a.applyList(n, "Items")
+		if n.Into != nil {
+			a.apply(n, "Into", nil, n.Into)
+		}
+		a.applyList(n, "IntoColumns")
+	case *sqlast.ReturningClause:
+		a.applyList(n, "Items")
+	case *sqlast.OnConflict:
+		a.applyList(n, "Target")
+		if n.TargetWhere != nil {
+			a.apply(n, "TargetWhere", nil, n.TargetWhere)
+		}
+		if n.Constraint != nil {
+			a.apply(n, "Constraint", nil, n.Constraint)
+		}
+		a.applyList(n, "Assignments")
 		if n.Selection != nil {
 			a.apply(n, "Selection", nil, n.Selection)
 		}
@@ -353,7 +553,10 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		a.apply(n, "Name", nil, n.Name)
 		a.applyList(n, "Elements")
 	case *sqlast.Assignment:
-		a.apply(n, "ID", nil, n.ID)
+		if n.ID != nil {
+			a.apply(n, "ID", nil, n.ID)
+		}
+		a.applyList(n, "Columns")
 		a.apply(n, "Value", nil, n.Value)
 	case *sqlast.TableConstraint:
 		if n.Name != nil {
@@ -389,6 +592,9 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 	case *sqlast.ReferencesColumnSpec:
 		a.apply(n, "TableName", nil, n.TableName)
 		a.applyList(n, "Columns")
+		a.applyList(n, "Actions")
+	case *sqlast.ReferentialAction:
+		// nothing to do
 	case *sqlast.CheckColumnSpec:
 		a.apply(n, "Expr", nil, n.Expr)
 	case *sqlast.AlterTableStmt:
@@ -433,13 +639,38 @@ func (a *application) apply(parent sqlast.Node, name string, iter *iterator, n s
 		a.applyList(n, "IndexNames")
 	case *sqlast.ExplainStmt:
 		a.apply(n, "Stmt", nil, n.Stmt)
+	case *sqlast.CreateVirtualTableStmt:
+		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "Using", nil, n.Using)
+	case *sqlast.PragmaStmt:
+		a.apply(n, "Name", nil, n.Name)
+		if n.Value != nil {
+			a.apply(n, "Value", nil, n.Value)
+		}
+	case *sqlast.AttachStmt:
+		a.apply(n, "File", nil, n.File)
+		a.apply(n, "Schema", nil, n.Schema)
+	case *sqlast.DetachStmt:
+		a.apply(n, "Schema", nil, n.Schema)
+	case *sqlast.BadStmt:
+		// nothing to do
+	case *sqlast.RawStmt:
+		// nothing to do
 	case *sqlast.Operator:
 		// nothing to do
+	case *sqlast.Placeholder:
+		// nothing to do
+	case *sqlast.DefaultValue:
+		// nothing to do
 	case *sqlast.NullValue,
 		*sqlast.LongValue,
 		*sqlast.DoubleValue,
 		*sqlast.SingleQuotedString,
 		*sqlast.NationalStringLiteral,
+		*sqlast.EscapedStringLiteral,
+		*sqlast.BitStringLiteral,
+		*sqlast.HexStringLiteral,
+		*sqlast.DollarQuotedString,
 		*sqlast.BooleanValue,
 		*sqlast.DateValue,
 		*sqlast.TimeValue,
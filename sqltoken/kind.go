@@ -14,6 +14,14 @@ const (
 	SingleQuotedString
 	// National string i.e: N'string'
 	NationalStringLiteral
+	// Escape string i.e: E'string\n' (PostgreSQL)
+	EscapedStringLiteral
+	// Bit string i.e: B'0101'
+	BitStringLiteral
+	// Hex string i.e: X'1F'
+	HexStringLiteral
+	// Dollar-quoted string i.e: $$string$$ or $tag$string$tag$ (PostgreSQL)
+	DollarQuotedString
 	// Comma
 	Comma
 	// Whitespace
@@ -66,6 +74,48 @@ const (
 	LBrace
 	// Right brace `}`
 	RBrace
+	// ||
+	DoublePipe
+	// |
+	Pipe
+	// ^
+	Caret
+	// ~
+	Tilde
+	// ~*
+	TildeAsterisk
+	// !~
+	ExclamationTilde
+	// !~*
+	ExclamationTildeAsterisk
+	// #
+	Sharp
+	// <<
+	ShiftLeft
+	// >>
+	ShiftRight
+	// <=>
+	Spaceship
+	// ->
+	Arrow
+	// ->>
+	LongArrow
+	// #>
+	HashArrow
+	// #>>
+	HashLongArrow
+	// @>
+	AtArrow
+	// <@
+	ArrowAt
+	// ?|
+	QuestionPipe
+	// ?&
+	QuestionAmpersand
+	// &&
+	DoubleAmpersand
+	// Bind parameter `?`, `$1` or `:name`
+	Placeholder
 	// ILLEGAL sqltoken
 	ILLEGAL
 )
@@ -13,38 +13,63 @@ func _() {
 	_ = x[Char-2]
 	_ = x[SingleQuotedString-3]
 	_ = x[NationalStringLiteral-4]
-	_ = x[Comma-5]
-	_ = x[Whitespace-6]
-	_ = x[Comment-7]
-	_ = x[Eq-8]
-	_ = x[Neq-9]
-	_ = x[Lt-10]
-	_ = x[Gt-11]
-	_ = x[LtEq-12]
-	_ = x[GtEq-13]
-	_ = x[Plus-14]
-	_ = x[Minus-15]
-	_ = x[Mult-16]
-	_ = x[Div-17]
-	_ = x[Mod-18]
-	_ = x[LParen-19]
-	_ = x[RParen-20]
-	_ = x[Period-21]
-	_ = x[Colon-22]
-	_ = x[DoubleColon-23]
-	_ = x[Semicolon-24]
-	_ = x[Backslash-25]
-	_ = x[LBracket-26]
-	_ = x[RBracket-27]
-	_ = x[Ampersand-28]
-	_ = x[LBrace-29]
-	_ = x[RBrace-30]
-	_ = x[ILLEGAL-31]
+	_ = x[EscapedStringLiteral-5]
+	_ = x[BitStringLiteral-6]
+	_ = x[HexStringLiteral-7]
+	_ = x[DollarQuotedString-8]
+	_ = x[Comma-9]
+	_ = x[Whitespace-10]
+	_ = x[Comment-11]
+	_ = x[Eq-12]
+	_ = x[Neq-13]
+	_ = x[Lt-14]
+	_ = x[Gt-15]
+	_ = x[LtEq-16]
+	_ = x[GtEq-17]
+	_ = x[Plus-18]
+	_ = x[Minus-19]
+	_ = x[Mult-20]
+	_ = x[Div-21]
+	_ = x[Mod-22]
+	_ = x[LParen-23]
+	_ = x[RParen-24]
+	_ = x[Period-25]
+	_ = x[Colon-26]
+	_ = x[DoubleColon-27]
+	_ = x[Semicolon-28]
+	_ = x[Backslash-29]
+	_ = x[LBracket-30]
+	_ = x[RBracket-31]
+	_ = x[Ampersand-32]
+	_ = x[LBrace-33]
+	_ = x[RBrace-34]
+	_ = x[DoublePipe-35]
+	_ = x[Pipe-36]
+	_ = x[Caret-37]
+	_ = x[Tilde-38]
+	_ = x[TildeAsterisk-39]
+	_ = x[ExclamationTilde-40]
+	_ = x[ExclamationTildeAsterisk-41]
+	_ = x[Sharp-42]
+	_ = x[ShiftLeft-43]
+	_ = x[ShiftRight-44]
+	_ = x[Spaceship-45]
+	_ = x[Arrow-46]
+	_ = x[LongArrow-47]
+	_ = x[HashArrow-48]
+	_ = x[HashLongArrow-49]
+	_ = x[AtArrow-50]
+	_ = x[ArrowAt-51]
+	_ = x[QuestionPipe-52]
+	_ = x[QuestionAmpersand-53]
+	_ = x[DoubleAmpersand-54]
+	_ = x[Placeholder-55]
+	_ = x[ILLEGAL-56]
 }
 
-const _Kind_name = "SQLKeywordNumberCharSingleQuotedStringNationalStringLiteralCommaWhitespaceCommentEqNeqLtGtLtEqGtEqPlusMinusMultDivModLParenRParenPeriodColonDoubleColonSemicolonBackslashLBracketRBracketAmpersandLBraceRBraceILLEGAL"
+const _Kind_name = "SQLKeywordNumberCharSingleQuotedStringNationalStringLiteralEscapedStringLiteralBitStringLiteralHexStringLiteralDollarQuotedStringCommaWhitespaceCommentEqNeqLtGtLtEqGtEqPlusMinusMultDivModLParenRParenPeriodColonDoubleColonSemicolonBackslashLBracketRBracketAmpersandLBraceRBraceDoublePipePipeCaretTildeTildeAsteriskExclamationTildeExclamationTildeAsteriskSharpShiftLeftShiftRightSpaceshipArrowLongArrowHashArrowHashLongArrowAtArrowArrowAtQuestionPipeQuestionAmpersandDoubleAmpersandPlaceholderILLEGAL"
 
-var _Kind_index = [...]uint8{0, 10, 16, 20, 38, 59, 64, 74, 81, 83, 86, 88, 90, 94, 98, 102, 107, 111, 114, 117, 123, 129, 135, 140, 151, 160, 169, 177, 185, 194, 200, 206, 213}
+var _Kind_index = [...]uint16{0, 10, 16, 20, 38, 59, 79, 95, 111, 129, 134, 144, 151, 153, 156, 158, 160, 164, 168, 172, 177, 181, 184, 187, 193, 199, 205, 210, 221, 230, 239, 247, 255, 264, 270, 276, 286, 290, 295, 300, 313, 329, 353, 358, 367, 377, 386, 391, 400, 409, 422, 429, 436, 448, 465, 480, 491, 498}
 
 func (i Kind) String() string {
 	if i < 0 || i >= Kind(len(_Kind_index)-1) {
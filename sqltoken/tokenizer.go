@@ -3,14 +3,38 @@ package sqltoken
 import (
 	"fmt"
 	"io"
+	"reflect"
 	"strings"
+	"sync"
 	"text/scanner"
+	"unicode"
 
 	errors "golang.org/x/xerrors"
 
 	"github.com/moomou/xsqlparser/dialect"
 )
 
+// DollarQuote is the value of DollarQuotedString token.
+type DollarQuote struct {
+	Tag   string
+	Value string
+}
+
+func (d *DollarQuote) String() string {
+	return "$" + d.Tag + "$" + d.Value + "$" + d.Tag + "$"
+}
+
+// PrefixedString is the value of EscapedStringLiteral, BitStringLiteral and HexStringLiteral token.
+// Prefix is E, B or X in the case as written.
+type PrefixedString struct {
+	Prefix string
+	Value  string
+}
+
+func (p *PrefixedString) String() string {
+	return p.Prefix + "'" + p.Value + "'"
+}
+
 type SQLWord struct {
 	Value      string
 	QuoteStyle rune
@@ -38,32 +62,53 @@ func matchingEndQuote(quoteStyle rune) rune {
 	return 0
 }
 
-var keywordCache = map[string]*SQLWord{}
+// keywordCaches holds a keywordCache per dialect type.
+var keywordCaches sync.Map
+
+// keywordCache maps keywords of a dialect in upper and lower case to shared SQLWords.
+type keywordCache map[string]*SQLWord
 
-func init() {
-	for keyword := range dialect.Keywords {
-		keywordCache[keyword] = &SQLWord{
-			Value:      keyword,
-			Keyword:    keyword,
+func keywordCacheOf(d dialect.Dialect) keywordCache {
+	typ := reflect.TypeOf(d)
+	if c, ok := keywordCaches.Load(typ); ok {
+		return c.(keywordCache)
+	}
+
+	c := keywordCache{}
+	for keyword := range d.Keywords() {
+		c[keyword] = &SQLWord{
+			Value:   keyword,
+			Keyword: keyword,
 		}
 		lower := strings.ToLower(keyword)
-		keywordCache[lower] = &SQLWord{
-			Value:      lower,
-			Keyword:    keyword,
+		c[lower] = &SQLWord{
+			Value:   lower,
+			Keyword: keyword,
 		}
 	}
+	cached, _ := keywordCaches.LoadOrStore(typ, c)
+	return cached.(keywordCache)
 }
 
+// MakeKeyword returns the SQLWord of word using the keywords of GenericSQLDialect.
 func MakeKeyword(word string, quoteStyle rune) *SQLWord {
+	return makeKeyword(&dialect.GenericSQLDialect{}, word, quoteStyle)
+}
+
+// makeKeyword returns the SQLWord of word.
+// Unquoted words which are not keywords of d have an empty Keyword.
+func makeKeyword(d dialect.Dialect, word string, quoteStyle rune) *SQLWord {
 	if quoteStyle == 0 {
-		if w, ok := keywordCache[word]; ok {
+		if w, ok := keywordCacheOf(d)[word]; ok {
 			return w
 		}
 	}
 	w := strings.ToUpper(word)
-	_, ok := dialect.Keywords[w]
 
-	if quoteStyle == 0 && ok {
+	if quoteStyle == 0 {
+		if _, ok := d.Keywords()[w]; !ok {
+			w = ""
+		}
 		return &SQLWord{
 			Value:   word,
 			Keyword: w,
@@ -82,6 +127,10 @@ type Token struct {
 	Value interface{}
 	From  Pos
 	To    Pos
+
+	// byte offsets of the token in the source
+	FromOffset int
+	ToOffset   int
 }
 
 func NewPos(line, col int) Pos {
@@ -187,6 +236,7 @@ func (t *Tokenizer) NextToken() (*Token, error) {
 
 func (t *Tokenizer) Scan(token *Token) (*Token, error) {
 	pos := t.Pos()
+	offset := t.Scanner.Pos().Offset
 	tok, str, err := t.next()
 	if err == io.EOF {
 		return nil, io.EOF
@@ -196,6 +246,8 @@ func (t *Tokenizer) Scan(token *Token) (*Token, error) {
 		token.Value = ""
 		token.From = pos
 		token.To = t.Pos()
+		token.FromOffset = offset
+		token.ToOffset = t.Scanner.Pos().Offset
 		return token, errors.Errorf("tokenize failed: %w", err)
 	}
 
@@ -207,6 +259,8 @@ func (t *Tokenizer) Scan(token *Token) (*Token, error) {
 	token.Value = str
 	token.From = pos
 	token.To = t.Pos()
+	token.FromOffset = offset
+	token.ToOffset = t.Scanner.Pos().Offset
 	return token, nil
 }
 
@@ -258,13 +312,75 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 			return NationalStringLiteral, str, nil
 		}
 		s := t.tokenizeWord('N')
-		v := MakeKeyword(s, 0)
+		v := makeKeyword(t.Dialect, s, 0)
 		return SQLKeyword, v, nil
 
+	case 'E' == r || 'e' == r || 'B' == r || 'b' == r || 'X' == r || 'x' == r:
+		t.Scanner.Next()
+		// E'...' is only a string in the dialects which have escape string constants
+		if t.Scanner.Peek() != '\'' ||
+			((r == 'E' || r == 'e') && !t.Dialect.Supports(dialect.EscapeString)) {
+			s := t.tokenizeWord(r)
+			return SQLKeyword, makeKeyword(t.Dialect, s, 0), nil
+		}
+		t.Col += 1
+
+		switch r {
+		case 'E', 'e':
+			str, err := t.tokenizeEscapedString()
+			if err != nil {
+				return ILLEGAL, "", err
+			}
+			return EscapedStringLiteral, &PrefixedString{Prefix: string(r), Value: str}, nil
+		case 'B', 'b':
+			str, err := t.tokenizeSingleQuotedString()
+			if err != nil {
+				return ILLEGAL, "", err
+			}
+			return BitStringLiteral, &PrefixedString{Prefix: string(r), Value: str}, nil
+		default:
+			str, err := t.tokenizeSingleQuotedString()
+			if err != nil {
+				return ILLEGAL, "", err
+			}
+			return HexStringLiteral, &PrefixedString{Prefix: string(r), Value: str}, nil
+		}
+
+	case '@' == r:
+		t.Scanner.Next()
+		if t.Scanner.Peek() == '>' {
+			t.Scanner.Next()
+			t.Col += 2
+			return AtArrow, "@>", nil
+		}
+		// @name is a word which the parser takes as a placeholder
+		if t.Dialect.IsIdentifierStart(r) ||
+			(t.Dialect.Supports(dialect.NamedPlaceholder) && t.Dialect.IsIdentifierStart(t.Scanner.Peek())) {
+			s := t.tokenizeWord(r)
+			return SQLKeyword, makeKeyword(t.Dialect, s, 0), nil
+		}
+		t.Col += 1
+		return Char, "@", nil
+
+	case '#' == r && !t.Dialect.IsIdentifierStart(r):
+		t.Scanner.Next()
+		if t.Scanner.Peek() != '>' {
+			t.Col += 1
+			return Sharp, "#", nil
+		}
+		t.Scanner.Next()
+		if t.Scanner.Peek() == '>' {
+			t.Scanner.Next()
+			t.Col += 3
+			return HashLongArrow, "#>>", nil
+		}
+		t.Col += 2
+		return HashArrow, "#>", nil
+
 	case t.Dialect.IsIdentifierStart(r):
 		t.Scanner.Next()
 		s := t.tokenizeWord(r)
-		return SQLKeyword, MakeKeyword(s, 0), nil
+		return SQLKeyword, makeKeyword(t.Dialect, s, 0), nil
 
 	case '\'' == r:
 		s, err := t.tokenizeSingleQuotedString()
@@ -283,11 +399,14 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 			if n == end {
 				break
 			}
+			if n == scanner.EOF {
+				return ILLEGAL, "", errors.Errorf("unclosed quoted identifier: %s at %+v", string(s), t.Pos())
+			}
 			s = append(s, n)
 		}
 		t.Col += 2 + len(s)
 
-		return SQLKeyword, MakeKeyword(string(s), r), nil
+		return SQLKeyword, makeKeyword(t.Dialect, string(s), r), nil
 
 	case '0' <= r && r <= '9':
 		var s []rune
@@ -336,6 +455,16 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 				}
 			}
 		}
+		if '>' == t.Scanner.Peek() {
+			t.Scanner.Next()
+			if '>' == t.Scanner.Peek() {
+				t.Scanner.Next()
+				t.Col += 3
+				return LongArrow, "->>", nil
+			}
+			t.Col += 2
+			return Arrow, "->", nil
+		}
 		t.Col += 1
 		return Minus, "-", nil
 
@@ -382,6 +511,16 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 			t.Col += 2
 			return Neq, "!=", nil
 		}
+		if n == '~' {
+			t.Scanner.Next()
+			if t.Scanner.Peek() == '*' {
+				t.Scanner.Next()
+				t.Col += 3
+				return ExclamationTildeAsterisk, "!~*", nil
+			}
+			t.Col += 2
+			return ExclamationTilde, "!~", nil
+		}
 		return ILLEGAL, "", errors.Errorf("tokenizer error: illegal sequence %s%s", string(r), string(n))
 
 	case '<' == r:
@@ -389,12 +528,25 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 		switch t.Scanner.Peek() {
 		case '=':
 			t.Scanner.Next()
+			if t.Scanner.Peek() == '>' {
+				t.Scanner.Next()
+				t.Col += 3
+				return Spaceship, "<=>", nil
+			}
 			t.Col += 2
 			return LtEq, "<=", nil
 		case '>':
 			t.Scanner.Next()
 			t.Col += 2
 			return Neq, "<>", nil
+		case '<':
+			t.Scanner.Next()
+			t.Col += 2
+			return ShiftLeft, "<<", nil
+		case '@':
+			t.Scanner.Next()
+			t.Col += 2
+			return ArrowAt, "<@", nil
 		default:
 			t.Col += 1
 			return Lt, "<", nil
@@ -406,6 +558,10 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 			t.Scanner.Next()
 			t.Col += 2
 			return GtEq, ">=", nil
+		case '>':
+			t.Scanner.Next()
+			t.Col += 2
+			return ShiftRight, ">>", nil
 		default:
 			t.Col += 1
 			return Gt, ">", nil
@@ -418,8 +574,70 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 			t.Col += 2
 			return DoubleColon, "::", nil
 		}
+		if t.Dialect.IsIdentifierStart(n) && n != '@' {
+			t.Scanner.Next()
+			s := ":" + t.tokenizeWord(n)
+			t.Col += 1
+			return Placeholder, s, nil
+		}
 		t.Col += 1
 		return Colon, ":", nil
+	case '?' == r:
+		t.Scanner.Next()
+		switch t.Scanner.Peek() {
+		case '|':
+			t.Scanner.Next()
+			t.Col += 2
+			return QuestionPipe, "?|", nil
+		case '&':
+			t.Scanner.Next()
+			t.Col += 2
+			return QuestionAmpersand, "?&", nil
+		}
+		s := "?"
+		if t.Dialect.Supports(dialect.NumberedQuestionPlaceholder) {
+			for n := t.Scanner.Peek(); '0' <= n && n <= '9'; n = t.Scanner.Peek() {
+				t.Scanner.Next()
+				s += string(n)
+			}
+		}
+		t.Col += len(s)
+		return Placeholder, s, nil
+	case '$' == r:
+		t.Scanner.Next()
+		var s []rune
+		for {
+			n := t.Scanner.Peek()
+			if '0' <= n && n <= '9' {
+				s = append(s, n)
+				t.Scanner.Next()
+			} else {
+				break
+			}
+		}
+		if len(s) != 0 {
+			t.Col += 1 + len(s)
+			return Placeholder, "$" + string(s), nil
+		}
+		if n := t.Scanner.Peek(); n == '$' || n == '_' || unicode.IsLetter(n) {
+			var tag strings.Builder
+			for n := t.Scanner.Peek(); n == '_' || unicode.IsLetter(n) || unicode.IsDigit(n); n = t.Scanner.Peek() {
+				t.Scanner.Next()
+				tag.WriteRune(n)
+			}
+			// $name is a placeholder unless it starts a dollar-quoted string like $name$...$name$
+			if t.Scanner.Peek() != '$' && t.Dialect.Supports(dialect.NamedPlaceholder) {
+				t.Col += 1 + tag.Len()
+				return Placeholder, "$" + tag.String(), nil
+			}
+			q, err := t.tokenizeDollarQuotedString(tag.String())
+			if err != nil {
+				return ILLEGAL, "", err
+			}
+			return DollarQuotedString, q, nil
+		}
+		t.Col += 1
+		return Char, "$", nil
 	case ';' == r:
 		t.Scanner.Next()
 		t.Col += 1
@@ -438,8 +656,35 @@ func (t *Tokenizer) next() (Kind, interface{}, error) {
 		return RBracket, "]", nil
 	case '&' == r:
 		t.Scanner.Next()
+		if t.Scanner.Peek() == '&' {
+			t.Scanner.Next()
+			t.Col += 2
+			return DoubleAmpersand, "&&", nil
+		}
 		t.Col += 1
 		return Ampersand, "&", nil
+	case '|' == r:
+		t.Scanner.Next()
+		if t.Scanner.Peek() == '|' {
+			t.Scanner.Next()
+			t.Col += 2
+			return DoublePipe, "||", nil
+		}
+		t.Col += 1
+		return Pipe, "|", nil
+	case '^' == r:
+		t.Scanner.Next()
+		t.Col += 1
+		return Caret, "^", nil
+	case '~' == r:
+		t.Scanner.Next()
+		if t.Scanner.Peek() == '*' {
+			t.Scanner.Next()
+			t.Col += 2
+			return TildeAsterisk, "~*", nil
+		}
+		t.Col += 1
+		return Tilde, "~", nil
 	case '{' == r:
 		t.Scanner.Next()
 		t.Col += 1
@@ -505,6 +750,74 @@ func (t *Tokenizer) tokenizeSingleQuotedString() (string, error) {
 	return str, nil
 }
 
+// tokenizeEscapedString reads E'...' string after E.
+// Backslash escapes are kept as they are to write the string back.
+func (t *Tokenizer) tokenizeEscapedString() (string, error) {
+	var builder strings.Builder
+	t.Scanner.Next()
+	t.Col += 1
+	for {
+		n := t.Scanner.Next()
+		switch n {
+		case scanner.EOF:
+			return "", errors.Errorf("unclosed escape string: %s at %+v", builder.String(), t.Pos())
+		case '\\':
+			builder.WriteRune(n)
+			t.advance(n)
+			n = t.Scanner.Next()
+			if n == scanner.EOF {
+				return "", errors.Errorf("unclosed escape string: %s at %+v", builder.String(), t.Pos())
+			}
+		case '\'':
+			t.Col += 1
+			if t.Scanner.Peek() != '\'' {
+				return builder.String(), nil
+			}
+			builder.WriteRune(n)
+			n = t.Scanner.Next()
+		}
+		builder.WriteRune(n)
+		t.advance(n)
+	}
+}
+
+// tokenizeDollarQuotedString reads $tag$...$tag$ string after the first $ and the tag.
+func (t *Tokenizer) tokenizeDollarQuotedString(tag string) (*DollarQuote, error) {
+	if t.Scanner.Next() != '$' {
+		return nil, errors.Errorf("invalid dollar quote tag: $%s at %+v", tag, t.Pos())
+	}
+	t.Col += 2 + len(tag)
+
+	end := "$" + tag + "$"
+	var body strings.Builder
+	for {
+		n := t.Scanner.Next()
+		if n == scanner.EOF {
+			return nil, errors.Errorf("unclosed dollar-quoted string: %s at %+v", end, t.Pos())
+		}
+		body.WriteRune(n)
+		t.advance(n)
+
+		if n == '$' && strings.HasSuffix(body.String(), end) {
+			str := body.String()
+			return &DollarQuote{
+				Tag:   tag,
+				Value: str[:len(str)-len(end)],
+			}, nil
+		}
+	}
+}
+
+// advance moves the position over r which may be a new line.
+func (t *Tokenizer) advance(r rune) {
+	if r == '\n' {
+		t.Line += 1
+		t.Col = 1
+		return
+	}
+	t.Col += 1
+}
+
 func (t *Tokenizer) tokenizeMultilineComment() (string, error) {
 	var str []rune
 	var mayBeClosingComment bool
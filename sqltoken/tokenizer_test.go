@@ -342,15 +342,15 @@ comment */`,
 			in:   "<<=<>",
 			out: []*Token{
 				{
-					Kind:  Lt,
-					Value: "<",
+					Kind:  ShiftLeft,
+					Value: "<<",
 					From:  Pos{Line: 1, Col: 1},
-					To:    Pos{Line: 1, Col: 2},
+					To:    Pos{Line: 1, Col: 3},
 				},
 				{
-					Kind:  LtEq,
-					Value: "<=",
-					From:  Pos{Line: 1, Col: 2},
+					Kind:  Eq,
+					Value: "=",
+					From:  Pos{Line: 1, Col: 3},
 					To:    Pos{Line: 1, Col: 4},
 				},
 				{
@@ -366,15 +366,15 @@ comment */`,
 			in:   ">>=",
 			out: []*Token{
 				{
-					Kind:  Gt,
-					Value: ">",
+					Kind:  ShiftRight,
+					Value: ">>",
 					From:  Pos{Line: 1, Col: 1},
-					To:    Pos{Line: 1, Col: 2},
+					To:    Pos{Line: 1, Col: 3},
 				},
 				{
-					Kind:  GtEq,
-					Value: ">=",
-					From:  Pos{Line: 1, Col: 2},
+					Kind:  Eq,
+					Value: "=",
+					From:  Pos{Line: 1, Col: 3},
 					To:    Pos{Line: 1, Col: 4},
 				},
 			},
@@ -457,6 +457,126 @@ comment */`,
 				},
 			},
 		},
+		{
+			name: "placeholders",
+			in:   "?$12:id",
+			out: []*Token{
+				{
+					Kind:  Placeholder,
+					Value: "?",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 2},
+				},
+				{
+					Kind:  Placeholder,
+					Value: "$12",
+					From:  Pos{Line: 1, Col: 2},
+					To:    Pos{Line: 1, Col: 5},
+				},
+				{
+					Kind:  Placeholder,
+					Value: ":id",
+					From:  Pos{Line: 1, Col: 5},
+					To:    Pos{Line: 1, Col: 8},
+				},
+			},
+		},
+		{
+			name: "operators",
+			in:   "||->>#>>@><@?|!~*<=>",
+			out: []*Token{
+				{
+					Kind:  DoublePipe,
+					Value: "||",
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 3},
+				},
+				{
+					Kind:  LongArrow,
+					Value: "->>",
+					From:  Pos{Line: 1, Col: 3},
+					To:    Pos{Line: 1, Col: 6},
+				},
+				{
+					Kind:  HashLongArrow,
+					Value: "#>>",
+					From:  Pos{Line: 1, Col: 6},
+					To:    Pos{Line: 1, Col: 9},
+				},
+				{
+					Kind:  AtArrow,
+					Value: "@>",
+					From:  Pos{Line: 1, Col: 9},
+					To:    Pos{Line: 1, Col: 11},
+				},
+				{
+					Kind:  ArrowAt,
+					Value: "<@",
+					From:  Pos{Line: 1, Col: 11},
+					To:    Pos{Line: 1, Col: 13},
+				},
+				{
+					Kind:  QuestionPipe,
+					Value: "?|",
+					From:  Pos{Line: 1, Col: 13},
+					To:    Pos{Line: 1, Col: 15},
+				},
+				{
+					Kind:  ExclamationTildeAsterisk,
+					Value: "!~*",
+					From:  Pos{Line: 1, Col: 15},
+					To:    Pos{Line: 1, Col: 18},
+				},
+				{
+					Kind:  Spaceship,
+					Value: "<=>",
+					From:  Pos{Line: 1, Col: 18},
+					To:    Pos{Line: 1, Col: 21},
+				},
+			},
+		},
+		{
+			name: "escaped bit and hex strings",
+			in:   "E'a\\'b'B'01'x'1F'",
+			out: []*Token{
+				{
+					Kind:  EscapedStringLiteral,
+					Value: &PrefixedString{Prefix: "E", Value: "a\\'b"},
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 8},
+				},
+				{
+					Kind:  BitStringLiteral,
+					Value: &PrefixedString{Prefix: "B", Value: "01"},
+					From:  Pos{Line: 1, Col: 8},
+					To:    Pos{Line: 1, Col: 13},
+				},
+				{
+					Kind:  HexStringLiteral,
+					Value: &PrefixedString{Prefix: "x", Value: "1F"},
+					From:  Pos{Line: 1, Col: 13},
+					To:    Pos{Line: 1, Col: 18},
+				},
+			},
+		},
+		{
+			name: "dollar-quoted string",
+			in:   "$$a$$$tag$b\n$$c$tag$",
+			out: []*Token{
+				{
+					Kind:  DollarQuotedString,
+					Value: &DollarQuote{Value: "a"},
+					From:  Pos{Line: 1, Col: 1},
+					To:    Pos{Line: 1, Col: 6},
+				},
+				{
+					Kind:  DollarQuotedString,
+					Value: &DollarQuote{Tag: "tag", Value: "b\n$$c"},
+					From:  Pos{Line: 1, Col: 6},
+					To:    Pos{Line: 2, Col: 9},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -647,6 +767,155 @@ test
 	})
 }
 
+func TestTokenizer_Offset(t *testing.T) {
+	src := "SELECT\t'\u3042', `x`\n-- c\nFROM t"
+	tokens, err := NewTokenizer(bytes.NewBufferString(src), &dialect.MySQLDialect{}).Tokenize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	for i, tok := range tokens {
+		if i > 0 && tokens[i-1].ToOffset != tok.FromOffset {
+			t.Errorf("offsets must be contiguous but %d and %d", tokens[i-1].ToOffset, tok.FromOffset)
+		}
+		b.WriteString(src[tok.FromOffset:tok.ToOffset])
+	}
+
+	if b.String() != src {
+		t.Errorf("must be %q but %q", src, b.String())
+	}
+}
+
+func TestTokenizer_DelimitedIdentifier(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect dialect.Dialect
+		in      string
+		out     *SQLWord
+		err     bool
+	}{
+		{
+			name:    "double quote",
+			dialect: &dialect.GenericSQLDialect{},
+			in:      `"a b"`,
+			out:     &SQLWord{Value: "a b", Keyword: "A B", QuoteStyle: '"'},
+		},
+		{
+			name:    "backtick in mysql",
+			dialect: &dialect.MySQLDialect{},
+			in:      "`a b`",
+			out:     &SQLWord{Value: "a b", Keyword: "A B", QuoteStyle: '`'},
+		},
+		{
+			name:    "bracket in sqlite",
+			dialect: &dialect.SQLiteDialect{},
+			in:      "[a b]",
+			out:     &SQLWord{Value: "a b", Keyword: "A B", QuoteStyle: '['},
+		},
+		{
+			name:    "unclosed",
+			dialect: &dialect.SQLiteDialect{},
+			in:      "[a b",
+			err:     true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tokens, err := NewTokenizer(strings.NewReader(c.in), c.dialect).Tokenize()
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but %+v", tokens)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tokens) != 1 {
+				t.Fatalf("must be 1 token but %d", len(tokens))
+			}
+			if diff := cmp.Diff(c.out, tokens[0].Value); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+			if act := tokens[0].Value.(*SQLWord).String(); act != c.in {
+				t.Errorf("must be %s but %s", c.in, act)
+			}
+		})
+	}
+}
+
+func TestTokenizer_DialectKeywords(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect dialect.Dialect
+		in      string
+		out     *SQLWord
+	}{
+		{
+			name:    "common keyword",
+			dialect: &dialect.MySQLDialect{},
+			in:      "select",
+			out:     &SQLWord{Value: "select", Keyword: "SELECT"},
+		},
+		{
+			name:    "postgres keyword",
+			dialect: &dialect.PostgresqlDialect{},
+			in:      "ilike",
+			out:     &SQLWord{Value: "ilike", Keyword: "ILIKE"},
+		},
+		{
+			name:    "postgres keyword in mysql",
+			dialect: &dialect.MySQLDialect{},
+			in:      "ilike",
+			out:     &SQLWord{Value: "ilike"},
+		},
+		{
+			name:    "postgres keyword in generic",
+			dialect: &dialect.GenericSQLDialect{},
+			in:      "ILike",
+			out:     &SQLWord{Value: "ILike", Keyword: "ILIKE"},
+		},
+		{
+			name:    "mysql interval unit in postgres",
+			dialect: &dialect.PostgresqlDialect{},
+			in:      "quarter",
+			out:     &SQLWord{Value: "quarter"},
+		},
+		{
+			name:    "mssql keyword in postgres",
+			dialect: &dialect.PostgresqlDialect{},
+			in:      "nolock",
+			out:     &SQLWord{Value: "nolock"},
+		},
+		{
+			name:    "escape string prefix in mysql",
+			dialect: &dialect.MySQLDialect{},
+			in:      "e'x'",
+			out:     &SQLWord{Value: "e"},
+		},
+		{
+			name:    "quoted keyword",
+			dialect: &dialect.PostgresqlDialect{},
+			in:      `"ilike"`,
+			out:     &SQLWord{Value: "ilike", Keyword: "ILIKE", QuoteStyle: '"'},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tokens, err := NewTokenizer(strings.NewReader(c.in), c.dialect).Tokenize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.out, tokens[0].Value); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+		})
+	}
+}
+
 func BenchmarkTokenizer_Tokenize(b *testing.B) {
 	cases := []struct {
 		name string
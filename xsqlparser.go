@@ -0,0 +1,99 @@
+/*
+Package xsqlparser parses sql into the abstract syntax tree declared in package sqlast.
+
+For most use cases the package level functions are enough:
+
+	stmt, err := xsqlparser.Parse("SELECT * FROM test_table", xsqlparser.Dialect(&dialect.PostgresqlDialect{}))
+
+Use NewParser when the source is an io.Reader or statements have to be consumed one by one.
+*/
+package xsqlparser
+
+import (
+	"strings"
+
+	errors "golang.org/x/xerrors"
+
+	"github.com/moomou/xsqlparser/dialect"
+	"github.com/moomou/xsqlparser/sqlast"
+)
+
+// Parse parses a single statement. A trailing semicolon is allowed,
+// but any other token after the statement is an error.
+func Parse(sql string, opts ...ParserOption) (sqlast.Stmt, error) {
+	p, err := newStringParser(sql, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := p.ParseStatement()
+	if err != nil {
+		return nil, errors.Errorf("ParseStatement failed: %w", err)
+	}
+
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// ParseAll parses semicolon separated statements into a File.
+// With RecoverErrors, the File is returned together with Diagnostics when some statements failed.
+func ParseAll(sql string, opts ...ParserOption) (*sqlast.File, error) {
+	p, err := newStringParser(sql, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := p.ParseFile()
+	if diags, ok := err.(Diagnostics); ok {
+		return f, diags
+	} else if err != nil {
+		return nil, errors.Errorf("ParseFile failed: %w", err)
+	}
+
+	return f, nil
+}
+
+// ParseExprString parses a single expression such as `a + 1 > b`.
+func ParseExprString(sql string, opts ...ParserOption) (sqlast.Node, error) {
+	p, err := newStringParser(sql, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, errors.Errorf("ParseExpr failed: %w", err)
+	}
+
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+
+	return expr, nil
+}
+
+// ParseDataTypeString parses a single data type such as `varchar(255)`.
+func ParseDataTypeString(sql string, opts ...ParserOption) (sqlast.Type, error) {
+	p, err := newStringParser(sql, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tp, err := p.ParseDataType()
+	if err != nil {
+		return nil, errors.Errorf("ParseDataType failed: %w", err)
+	}
+
+	if err := p.expectEOF(); err != nil {
+		return nil, err
+	}
+
+	return tp, nil
+}
+
+func newStringParser(sql string, opts []ParserOption) (*Parser, error) {
+	return NewParser(strings.NewReader(sql), &dialect.GenericSQLDialect{}, opts...)
+}
@@ -0,0 +1,2471 @@
+package xsqlparser
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/moomou/xsqlparser/dialect"
+	"github.com/moomou/xsqlparser/sqlast"
+	"github.com/moomou/xsqlparser/sqltoken"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+		opts []ParserOption
+		err  bool
+	}{
+		{
+			name: "single statement",
+			in:   "SELECT a FROM t",
+			out:  "SELECT a FROM t",
+		},
+		{
+			name: "trailing semicolon",
+			in:   "SELECT a FROM t;",
+			out:  "SELECT a FROM t",
+		},
+		{
+			name: "trailing garbage",
+			in:   "SELECT a FROM t; SELECT b FROM t",
+			err:  true,
+		},
+		{
+			name: "mysql dialect",
+			in:   "SELECT `a` FROM t",
+			out:  "SELECT `a` FROM t",
+			opts: []ParserOption{Dialect(&dialect.MySQLDialect{})},
+		},
+		{
+			name: "within max depth",
+			in:   "SELECT ((1))",
+			out:  "SELECT ((1))",
+			opts: []ParserOption{MaxDepth(8)},
+		},
+		{
+			name: "exceeds max depth",
+			in:   "SELECT ((((((((1))))))))",
+			opts: []ParserOption{MaxDepth(8)},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt, err := Parse(c.in, c.opts...)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but parsed %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := stmt.ToSQLString(); act != c.out {
+				t.Errorf("must be %s but %s", c.out, act)
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	cases := []struct {
+		name  string
+		in    string
+		stmts int
+		opts  []ParserOption
+		err   bool
+	}{
+		{
+			name:  "multiple statements",
+			in:    "SELECT a FROM t; DELETE FROM t;",
+			stmts: 2,
+		},
+		{
+			name:  "with comments",
+			in:    "-- comment\nSELECT a FROM t",
+			stmts: 1,
+			opts:  []ParserOption{ParseComment()},
+		},
+		{
+			name:  "within max statements",
+			in:    "SELECT a FROM t; SELECT b FROM t",
+			stmts: 2,
+			opts:  []ParserOption{MaxStatements(2)},
+		},
+		{
+			name: "exceeds max statements",
+			in:   "SELECT a FROM t; SELECT b FROM t; SELECT c FROM t",
+			opts: []ParserOption{MaxStatements(2)},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, err := ParseAll(c.in, c.opts...)
+			if c.err {
+				if err == nil {
+					t.Fatal("must be error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if len(f.Stmts) != c.stmts {
+				t.Errorf("must be %d statements but %d", c.stmts, len(f.Stmts))
+			}
+		})
+	}
+}
+
+func TestParseExprString(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+		err  bool
+	}{
+		{
+			name: "binary expr",
+			in:   "a + 1 > b",
+			out:  "a + 1 > b",
+		},
+		{
+			name: "trailing garbage",
+			in:   "a + 1 b",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExprString(c.in)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but parsed %s", expr.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := expr.ToSQLString(); act != c.out {
+				t.Errorf("must be %s but %s", c.out, act)
+			}
+		})
+	}
+}
+
+func TestParseExprString_Operators(t *testing.T) {
+	mysql := Dialect(&dialect.MySQLDialect{})
+	pg := Dialect(&dialect.PostgresqlDialect{})
+	mssql := Dialect(&dialect.MSSQLDialect{})
+
+	cases := []struct {
+		name string
+		in   string
+		opts []ParserOption
+		op   sqlast.OperatorType // operator of the root BinaryExpr
+		err  bool
+	}{
+		{
+			name: "string concat",
+			in:   "a || b || c",
+			op:   sqlast.StringConcat,
+		},
+		{
+			name: "concat binds looser than plus",
+			in:   "a || b + 1",
+			op:   sqlast.StringConcat,
+		},
+		{
+			name: "json arrow binds tighter than comparison",
+			in:   "data ->> 'id' = '1'",
+			opts: []ParserOption{pg},
+			op:   sqlast.Eq,
+		},
+		{
+			name: "json path",
+			in:   "data #> '{a,b}' ->> 'c'",
+			opts: []ParserOption{pg},
+			op:   sqlast.LongArrow,
+		},
+		{
+			name: "containment",
+			in:   "tags @> '{x}' AND tags <@ '{x,y}'",
+			opts: []ParserOption{pg},
+			op:   sqlast.And,
+		},
+		{
+			name: "containment of array constructor",
+			in:   "tags @> ARRAY['x']",
+			opts: []ParserOption{pg},
+			op:   sqlast.AtArrow,
+		},
+		{
+			name: "overlap of array constructors",
+			in:   "ARRAY[1, 2] && ARRAY[]",
+			opts: []ParserOption{pg},
+			op:   sqlast.Overlap,
+		},
+		{
+			name: "jsonb key exists",
+			in:   "data ? 'a' OR data ?| '{a,b}'",
+			opts: []ParserOption{pg},
+			op:   sqlast.Or,
+		},
+		{
+			name: "bitwise and binds tighter than or",
+			in:   "a | b & c",
+			op:   sqlast.BitwiseOr,
+		},
+		{
+			name: "shift binds looser than plus",
+			in:   "x << 2 + 1",
+			op:   sqlast.ShiftLeft,
+		},
+		{
+			name: "caret binds tighter than multiply",
+			in:   "2 ^ 3 * 4",
+			op:   sqlast.Multiply,
+		},
+		{
+			name: "bitwise not",
+			in:   "~ a + 1",
+			op:   sqlast.Plus,
+		},
+		{
+			name: "regex",
+			in:   "name ~* '^a'",
+			opts: []ParserOption{pg},
+			op:   sqlast.RegexIMatch,
+		},
+		{
+			name: "hash xor",
+			in:   "a # b",
+			opts: []ParserOption{pg},
+			op:   sqlast.PGBitwiseXor,
+		},
+		{
+			name: "null-safe equal",
+			in:   "a <=> b",
+			opts: []ParserOption{mysql},
+			op:   sqlast.NullSafeEq,
+		},
+		{
+			name: "json arrow in mysql",
+			in:   "data -> '$.id'",
+			opts: []ParserOption{mysql},
+			op:   sqlast.Arrow,
+		},
+		{
+			name: "regex in mysql",
+			in:   "name ~ '^a'",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "null-safe equal in postgresql",
+			in:   "a <=> b",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "json arrow in mssql",
+			in:   "data -> 'id'",
+			opts: []ParserOption{mssql},
+			err:  true,
+		},
+		{
+			name: "array constructor in mysql",
+			in:   "tags = ARRAY['x']",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExprString(c.in, c.opts...)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but parsed %s", expr.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := expr.ToSQLString(); act != c.in {
+				t.Errorf("must be %s but %s", c.in, act)
+			}
+
+			b, ok := expr.(*sqlast.BinaryExpr)
+			if !ok {
+				t.Fatalf("must be BinaryExpr but %T", expr)
+			}
+			if b.Op.Type != c.op {
+				t.Errorf("must be %s but %s", (&sqlast.Operator{Type: c.op}).ToSQLString(), b.Op.ToSQLString())
+			}
+		})
+	}
+}
+
+// treeString writes binary expressions with parentheses around each of them to show the tree shape.
+func treeString(n sqlast.Node) string {
+	b, ok := n.(*sqlast.BinaryExpr)
+	if !ok {
+		return n.ToSQLString()
+	}
+	return fmt.Sprintf("(%s %s %s)", treeString(b.Left), b.Op.ToSQLString(), treeString(b.Right))
+}
+
+func TestParseExprString_Precedence(t *testing.T) {
+	mysql := Dialect(&dialect.MySQLDialect{})
+	pg := Dialect(&dialect.PostgresqlDialect{})
+	mssql := Dialect(&dialect.MSSQLDialect{})
+
+	cases := []struct {
+		name string
+		in   string
+		opts []ParserOption
+		out  string
+		op   sqlast.OperatorType // operator of the root BinaryExpr
+		err  bool
+	}{
+		{
+			name: "pipes as or in mysql",
+			in:   "a = 1 || b = 2",
+			opts: []ParserOption{mysql},
+			out:  "((a = 1) OR (b = 2))",
+			op:   sqlast.Or,
+		},
+		{
+			name: "pipes as or binds looser than and in mysql",
+			in:   "a || b AND c",
+			opts: []ParserOption{mysql},
+			out:  "(a OR (b AND c))",
+			op:   sqlast.Or,
+		},
+		{
+			name: "concat in postgresql",
+			in:   "a = 1 || b",
+			opts: []ParserOption{pg},
+			out:  "(a = (1 || b))",
+			op:   sqlast.Eq,
+		},
+		{
+			name: "other operators are left associative in postgresql",
+			in:   "a | b || c & d",
+			opts: []ParserOption{pg},
+			out:  "(((a | b) || c) & d)",
+			op:   sqlast.BitwiseAnd,
+		},
+		{
+			name: "shift and bitwise and in postgresql",
+			in:   "a & b << c",
+			opts: []ParserOption{pg},
+			out:  "((a & b) << c)",
+			op:   sqlast.ShiftLeft,
+		},
+		{
+			name: "bitwise levels in mysql",
+			in:   "a | b & c << d",
+			opts: []ParserOption{mysql},
+			out:  "(a | (b & (c << d)))",
+			op:   sqlast.BitwiseOr,
+		},
+		{
+			name: "exponent in postgresql",
+			in:   "2 ^ 3 ^ 2 * 4",
+			opts: []ParserOption{pg},
+			out:  "(((2 ^ 3) ^ 2) * 4)",
+			op:   sqlast.Multiply,
+		},
+		{
+			name: "exponent is not xor in postgresql",
+			in:   "2 ^ 3",
+			opts: []ParserOption{pg},
+			out:  "(2 ^ 3)",
+			op:   sqlast.Exponent,
+		},
+		{
+			name: "xor in mysql",
+			in:   "2 ^ 3",
+			opts: []ParserOption{mysql},
+			out:  "(2 ^ 3)",
+			op:   sqlast.BitwiseXor,
+		},
+		{
+			name: "pipes in mssql",
+			in:   "a || b",
+			opts: []ParserOption{mssql},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExprString(c.in, c.opts...)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but parsed %s", expr.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := treeString(expr); act != c.out {
+				t.Errorf("must be %s but %s", c.out, act)
+			}
+			if op := expr.(*sqlast.BinaryExpr).Op.Type; op != c.op {
+				t.Errorf("must be %s but %s", (&sqlast.Operator{Type: c.op}).ToSQLString(), (&sqlast.Operator{Type: op}).ToSQLString())
+			}
+
+			if _, err := ParseExprString(expr.ToSQLString(), c.opts...); err != nil {
+				t.Fatalf("%+v", err)
+			}
+		})
+	}
+}
+
+func TestParseExprString_Predicates(t *testing.T) {
+	mysql := Dialect(&dialect.MySQLDialect{})
+	pg := Dialect(&dialect.PostgresqlDialect{})
+
+	cases := []struct {
+		name string
+		in   string
+		opts []ParserOption
+		node sqlast.Node // type of the root node
+		err  bool
+	}{
+		{
+			name: "is distinct from",
+			in:   "a IS DISTINCT FROM b + 1",
+			node: &sqlast.IsDistinctFrom{},
+		},
+		{
+			name: "is not distinct from",
+			in:   "a IS NOT DISTINCT FROM b AND c",
+			node: &sqlast.BinaryExpr{},
+		},
+		{
+			name: "is true",
+			in:   "a IS TRUE",
+			node: &sqlast.IsBool{},
+		},
+		{
+			name: "is not unknown",
+			in:   "a = b IS NOT UNKNOWN",
+			node: &sqlast.IsBool{},
+		},
+		{
+			name: "ilike",
+			in:   "name NOT ILIKE 'a%'",
+			opts: []ParserOption{pg},
+			node: &sqlast.BinaryExpr{},
+		},
+		{
+			name: "similar to with escape",
+			in:   "name SIMILAR TO '%!_a%' ESCAPE '!'",
+			opts: []ParserOption{pg},
+			node: &sqlast.PatternEscape{},
+		},
+		{
+			name: "like with escape",
+			in:   "name NOT LIKE 'a!%%' ESCAPE '!' OR b",
+			node: &sqlast.BinaryExpr{},
+		},
+		{
+			name: "regexp",
+			in:   "name REGEXP '^a' AND name NOT RLIKE 'b$'",
+			opts: []ParserOption{mysql},
+			node: &sqlast.BinaryExpr{},
+		},
+		{
+			name: "ilike in mysql",
+			in:   "name ILIKE 'a%'",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "regexp in postgresql",
+			in:   "name REGEXP '^a'",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "escape on regexp",
+			in:   "name REGEXP '^a' ESCAPE '!'",
+			err:  true,
+		},
+		{
+			name: "is without predicate",
+			in:   "a IS NOT b",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExprString(c.in, c.opts...)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but parsed %s", expr.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := expr.ToSQLString(); act != c.in {
+				t.Errorf("must be %s but %s", c.in, act)
+			}
+			if reflect.TypeOf(expr) != reflect.TypeOf(c.node) {
+				t.Errorf("must be %T but %T", c.node, expr)
+			}
+		})
+	}
+}
+
+func TestParseExprString_TypedLiterals(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  sqlast.Node
+	}{
+		{
+			name: "date",
+			in:   "DATE '2020-01-02'",
+			out: &sqlast.DateValue{
+				From: sqltoken.NewPos(1, 1),
+				To:   sqltoken.NewPos(1, 18),
+				Date: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+				Raw:  "2020-01-02",
+			},
+		},
+		{
+			name: "time with time zone",
+			in:   "TIME WITH TIME ZONE '10:00:00Z'",
+			out: &sqlast.TimeValue{
+				From:         sqltoken.NewPos(1, 1),
+				To:           sqltoken.NewPos(1, 32),
+				Time:         time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+				WithTimeZone: true,
+				Raw:          "10:00:00Z",
+			},
+		},
+		{
+			name: "timestamp",
+			in:   "TIMESTAMP '2020-01-02 10:00:00'",
+			out: &sqlast.DateTimeValue{
+				From:     sqltoken.NewPos(1, 1),
+				To:       sqltoken.NewPos(1, 32),
+				DateTime: time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+				Raw:      "2020-01-02 10:00:00",
+			},
+		},
+		{
+			name: "timestamp without time zone",
+			in:   "TIMESTAMP WITHOUT TIME ZONE '2020-01-02 10:00:00'",
+			out: &sqlast.DateTimeValue{
+				From:            sqltoken.NewPos(1, 1),
+				To:              sqltoken.NewPos(1, 50),
+				DateTime:        time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+				WithoutTimeZone: true,
+				Raw:             "2020-01-02 10:00:00",
+			},
+		},
+		{
+			name: "time without time zone",
+			in:   "TIME WITHOUT TIME ZONE '10:00:00'",
+			out: &sqlast.TimeValue{
+				From:            sqltoken.NewPos(1, 1),
+				To:              sqltoken.NewPos(1, 34),
+				Time:            time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+				WithoutTimeZone: true,
+				Raw:             "10:00:00",
+			},
+		},
+		{
+			name: "timestamp with time zone in other format",
+			in:   "TIMESTAMP WITH TIME ZONE 'now'",
+			out: &sqlast.TimestampValue{
+				From: sqltoken.NewPos(1, 1),
+				To:   sqltoken.NewPos(1, 31),
+				Raw:  "now",
+			},
+		},
+		{
+			name: "interval string",
+			in:   "INTERVAL '1 day'",
+			out: &sqlast.IntervalValue{
+				Interval: sqltoken.NewPos(1, 1),
+				Value: &sqlast.SingleQuotedString{
+					From:   sqltoken.NewPos(1, 10),
+					To:     sqltoken.NewPos(1, 17),
+					String: "1 day",
+				},
+				To: sqltoken.NewPos(1, 17),
+			},
+		},
+		{
+			name: "interval with qualifier",
+			in:   "INTERVAL '1 2' DAY(3) TO SECOND(6)",
+			out: &sqlast.IntervalValue{
+				Interval: sqltoken.NewPos(1, 1),
+				Value: &sqlast.SingleQuotedString{
+					From:   sqltoken.NewPos(1, 10),
+					To:     sqltoken.NewPos(1, 15),
+					String: "1 2",
+				},
+				LeadingField:        "DAY",
+				LeadingPrecision:    uintPtr(3),
+				TrailingField:       "SECOND",
+				FractionalPrecision: uintPtr(6),
+				To:                  sqltoken.NewPos(1, 35),
+			},
+		},
+		{
+			name: "mysql interval",
+			in:   "INTERVAL 3 HOUR",
+			out: &sqlast.IntervalValue{
+				Interval: sqltoken.NewPos(1, 1),
+				Value: &sqlast.LongValue{
+					From: sqltoken.NewPos(1, 10),
+					To:   sqltoken.NewPos(1, 11),
+					Long: 3,
+				},
+				LeadingField: "HOUR",
+				To:           sqltoken.NewPos(1, 16),
+			},
+		},
+		{
+			name: "mysql interval of column",
+			in:   "INTERVAL t.days DAY",
+			out: &sqlast.IntervalValue{
+				Interval: sqltoken.NewPos(1, 1),
+				Value: &sqlast.CompoundIdent{
+					Idents: []*sqlast.Ident{
+						{Value: "t", From: sqltoken.NewPos(1, 10), To: sqltoken.NewPos(1, 11)},
+						{Value: "days", From: sqltoken.NewPos(1, 12), To: sqltoken.NewPos(1, 16)},
+					},
+				},
+				LeadingField: "DAY",
+				To:           sqltoken.NewPos(1, 20),
+			},
+		},
+		{
+			name: "mysql interval of expression",
+			in:   "INTERVAL n + 1 DAY",
+			out: &sqlast.IntervalValue{
+				Interval: sqltoken.NewPos(1, 1),
+				Value: &sqlast.BinaryExpr{
+					Left: &sqlast.Ident{Value: "n", From: sqltoken.NewPos(1, 10), To: sqltoken.NewPos(1, 11)},
+					Op:   &sqlast.Operator{Type: sqlast.Plus, From: sqltoken.NewPos(1, 12), To: sqltoken.NewPos(1, 13)},
+					Right: &sqlast.LongValue{
+						From: sqltoken.NewPos(1, 14),
+						To:   sqltoken.NewPos(1, 15),
+						Long: 1,
+					},
+				},
+				LeadingField: "DAY",
+				To:           sqltoken.NewPos(1, 19),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExprString(c.in)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if diff := cmp.Diff(c.out, expr); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+			if act := expr.ToSQLString(); act != c.in {
+				t.Errorf("must be %s but %s", c.in, act)
+			}
+		})
+	}
+}
+
+func TestParseExprString_SpecialFunctions(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		node sqlast.Node // type of the root node
+	}{
+		{
+			name: "extract",
+			in:   "EXTRACT(YEAR FROM ts)",
+			node: &sqlast.Extract{},
+		},
+		{
+			name: "substring from for",
+			in:   "SUBSTRING(s FROM 2 FOR 3)",
+			node: &sqlast.Substring{},
+		},
+		{
+			name: "substring with commas",
+			in:   "SUBSTRING(s, 2, 3)",
+			node: &sqlast.Function{},
+		},
+		{
+			name: "trim leading",
+			in:   "TRIM(LEADING 'x' FROM s)",
+			node: &sqlast.Trim{},
+		},
+		{
+			name: "trim chars",
+			in:   "TRIM('x' FROM s)",
+			node: &sqlast.Trim{},
+		},
+		{
+			name: "trim without from",
+			in:   "TRIM(s)",
+			node: &sqlast.Function{},
+		},
+		{
+			name: "position",
+			in:   "POSITION('a' IN s)",
+			node: &sqlast.Position{},
+		},
+		{
+			name: "overlay",
+			in:   "OVERLAY(s PLACING 'ab' FROM 2 FOR 3)",
+			node: &sqlast.Overlay{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExprString(c.in)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			checkRoundTrip(t, c.in, expr)
+			if reflect.TypeOf(expr) != reflect.TypeOf(c.node) {
+				t.Errorf("must be %T but %T", c.node, expr)
+			}
+		})
+	}
+}
+
+func TestParseExprString_Aggregates(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  *sqlast.Function
+	}{
+		{
+			name: "distinct",
+			in:   "COUNT(DISTINCT user_id)",
+			out: &sqlast.Function{
+				Name:     sqlast.NewObjectName("COUNT"),
+				Distinct: true,
+				Args:     []sqlast.Node{sqlast.NewIdent("user_id")},
+			},
+		},
+		{
+			name: "filter",
+			in:   "SUM(x) FILTER (WHERE y)",
+			out: &sqlast.Function{
+				Name:   sqlast.NewObjectName("SUM"),
+				Args:   []sqlast.Node{sqlast.NewIdent("x")},
+				Filter: sqlast.NewIdent("y"),
+			},
+		},
+		{
+			name: "within group",
+			in:   "percentile_cont(0.5) WITHIN GROUP (ORDER BY x)",
+			out: &sqlast.Function{
+				Name:        sqlast.NewObjectName("percentile_cont"),
+				Args:        []sqlast.Node{sqlast.NewDoubleValue(0.5)},
+				WithinGroup: []*sqlast.OrderByExpr{{Expr: sqlast.NewIdent("x")}},
+			},
+		},
+		{
+			name: "order by in args",
+			in:   "string_agg(name, ',' ORDER BY name)",
+			out: &sqlast.Function{
+				Name:    sqlast.NewObjectName("string_agg"),
+				Args:    []sqlast.Node{sqlast.NewIdent("name"), sqlast.NewSingleQuotedString(",")},
+				OrderBy: []*sqlast.OrderByExpr{{Expr: sqlast.NewIdent("name")}},
+			},
+		},
+		{
+			name: "separator",
+			in:   "GROUP_CONCAT(name SEPARATOR ';')",
+			out: &sqlast.Function{
+				Name:      sqlast.NewObjectName("GROUP_CONCAT"),
+				Args:      []sqlast.Node{sqlast.NewIdent("name")},
+				Separator: sqlast.NewSingleQuotedString(";"),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExprString(c.in)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if diff := cmp.Diff(c.out, expr, ignorePos); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+			checkRoundTrip(t, c.in, expr)
+		})
+	}
+}
+
+func TestParseExprString_Windows(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  *sqlast.Function
+	}{
+		{
+			name: "named window",
+			in:   "SUM(x) OVER w",
+			out: &sqlast.Function{
+				Name:     sqlast.NewObjectName("SUM"),
+				Args:     []sqlast.Node{sqlast.NewIdent("x")},
+				OverName: sqlast.NewIdent("w"),
+			},
+		},
+		{
+			name: "inherit window",
+			in:   "rank() OVER (w ORDER BY x DESC)",
+			out: &sqlast.Function{
+				Name: sqlast.NewObjectName("rank"),
+				Over: &sqlast.WindowSpec{
+					Name:    sqlast.NewIdent("w"),
+					OrderBy: []*sqlast.OrderByExpr{{Expr: sqlast.NewIdent("x"), ASC: boolPtr(false)}},
+				},
+			},
+		},
+		{
+			name: "groups with exclude",
+			in:   "avg(x) OVER (ORDER BY y GROUPS BETWEEN 1 PRECEDING AND CURRENT ROW EXCLUDE TIES)",
+			out: &sqlast.Function{
+				Name: sqlast.NewObjectName("avg"),
+				Args: []sqlast.Node{sqlast.NewIdent("x")},
+				Over: &sqlast.WindowSpec{
+					OrderBy: []*sqlast.OrderByExpr{{Expr: sqlast.NewIdent("y")}},
+					WindowsFrame: &sqlast.WindowFrame{
+						Units:      &sqlast.WindowFrameUnit{Type: sqlast.GroupsUnit},
+						StartBound: &sqlast.Preceding{Bound: sqlast.NewLongValue(1)},
+						EndBound:   &sqlast.CurrentRow{},
+						Exclude:    &sqlast.WindowFrameExclude{Type: sqlast.ExcludeTies},
+					},
+				},
+			},
+		},
+		{
+			name: "interval offset",
+			in:   "sum(x) OVER (ORDER BY d RANGE BETWEEN INTERVAL '1 day' PRECEDING AND CURRENT ROW)",
+			out: &sqlast.Function{
+				Name: sqlast.NewObjectName("sum"),
+				Args: []sqlast.Node{sqlast.NewIdent("x")},
+				Over: &sqlast.WindowSpec{
+					OrderBy: []*sqlast.OrderByExpr{{Expr: sqlast.NewIdent("d")}},
+					WindowsFrame: &sqlast.WindowFrame{
+						Units: &sqlast.WindowFrameUnit{Type: sqlast.RangeUnit},
+						StartBound: &sqlast.Preceding{
+							Bound: &sqlast.IntervalValue{Value: sqlast.NewSingleQuotedString("1 day")},
+						},
+						EndBound: &sqlast.CurrentRow{},
+					},
+				},
+			},
+		},
+		{
+			name: "placeholder and expression offsets",
+			in:   "sum(x) OVER (ROWS BETWEEN $1 PRECEDING AND n + 1 FOLLOWING)",
+			out: &sqlast.Function{
+				Name: sqlast.NewObjectName("sum"),
+				Args: []sqlast.Node{sqlast.NewIdent("x")},
+				Over: &sqlast.WindowSpec{
+					WindowsFrame: &sqlast.WindowFrame{
+						Units: &sqlast.WindowFrameUnit{Type: sqlast.RowsUnit},
+						StartBound: &sqlast.Preceding{
+							Bound: &sqlast.Placeholder{Style: sqlast.DollarPlaceholder, Index: 1},
+						},
+						EndBound: &sqlast.Following{
+							Bound: &sqlast.BinaryExpr{
+								Left:  sqlast.NewIdent("n"),
+								Op:    &sqlast.Operator{Type: sqlast.Plus},
+								Right: sqlast.NewLongValue(1),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "exclude no others",
+			in:   "max(x) OVER (ROWS UNBOUNDED PRECEDING EXCLUDE NO OTHERS)",
+			out: &sqlast.Function{
+				Name: sqlast.NewObjectName("max"),
+				Args: []sqlast.Node{sqlast.NewIdent("x")},
+				Over: &sqlast.WindowSpec{
+					WindowsFrame: &sqlast.WindowFrame{
+						Units:      &sqlast.WindowFrameUnit{Type: sqlast.RowsUnit},
+						StartBound: &sqlast.UnboundedPreceding{},
+						Exclude:    &sqlast.WindowFrameExclude{Type: sqlast.ExcludeNoOthers},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expr, err := ParseExprString(c.in)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if diff := cmp.Diff(c.out, expr, ignorePos); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+			checkRoundTrip(t, c.in, expr)
+		})
+	}
+}
+
+func TestParser_WindowClause(t *testing.T) {
+	in := "SELECT SUM(x) OVER w FROM t WINDOW w AS (PARTITION BY y), w2 AS (w ORDER BY z)"
+	stmt := parseRoundTrip(t, in)
+
+	sel := stmt.(*sqlast.QueryStmt).Body.(*sqlast.SQLSelect)
+	if len(sel.FromClause) != 1 || len(sel.WindowClause) != 2 {
+		t.Fatalf("unexpected select %+v", sel)
+	}
+	if act := sel.WindowClause[1].Spec.Name.Value; act != "w" {
+		t.Errorf("must be based on w but %s", act)
+	}
+}
+
+func uintPtr(u uint) *uint {
+	return &u
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// ignorePos compares nodes without their positions, which checkRoundTrip checks by End.
+var ignorePos = cmp.Options{IgnoreMarker, cmpopts.IgnoreTypes(sqltoken.Pos{})}
+
+// parseRoundTrip parses in as a single statement and checks it by checkRoundTrip.
+func parseRoundTrip(t *testing.T, in string, opts ...ParserOption) sqlast.Stmt {
+	t.Helper()
+
+	stmt, err := Parse(in, opts...)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	checkRoundTrip(t, in, stmt)
+	return stmt
+}
+
+// checkRoundTrip checks that node parsed from in is written back as in and ends at the end of in.
+func checkRoundTrip(t *testing.T, in string, node sqlast.Node) {
+	t.Helper()
+
+	if act := node.ToSQLString(); act != in {
+		t.Errorf("must be %s but %s", in, act)
+	}
+	if act := node.End(); act != sqltoken.NewPos(1, len(in)+1) {
+		t.Errorf("must end at %+v but %+v", sqltoken.NewPos(1, len(in)+1), act)
+	}
+}
+
+func TestParser_GroupBy(t *testing.T) {
+	mysql := Dialect(&dialect.MySQLDialect{})
+
+	cases := []struct {
+		name  string
+		in    string
+		opts  []ParserOption
+		check func(t *testing.T, sel *sqlast.SQLSelect)
+	}{
+		{
+			name: "rollup",
+			in:   "SELECT a, b, SUM(c) FROM t GROUP BY ROLLUP (a, b)",
+			check: func(t *testing.T, sel *sqlast.SQLSelect) {
+				r, ok := sel.GroupByClause[0].(*sqlast.Rollup)
+				if !ok || len(r.Elements) != 2 {
+					t.Errorf("must be ROLLUP of 2 elements but %#v", sel.GroupByClause[0])
+				}
+			},
+		},
+		{
+			name: "cube with composite element",
+			in:   "SELECT a, b, c FROM t GROUP BY a, CUBE ((a, b), c)",
+			check: func(t *testing.T, sel *sqlast.SQLSelect) {
+				c, ok := sel.GroupByClause[1].(*sqlast.Cube)
+				if !ok {
+					t.Fatalf("must be CUBE but %T", sel.GroupByClause[1])
+				}
+				if _, ok := c.Elements[0].(*sqlast.GroupingSet); !ok {
+					t.Errorf("must be GroupingSet but %T", c.Elements[0])
+				}
+			},
+		},
+		{
+			name: "grouping sets",
+			in:   "SELECT a, b, GROUPING(a, b) FROM t GROUP BY GROUPING SETS ((a), (a, b), ())",
+			check: func(t *testing.T, sel *sqlast.SQLSelect) {
+				g, ok := sel.GroupByClause[0].(*sqlast.GroupingSets)
+				if !ok || len(g.Sets) != 3 {
+					t.Fatalf("must be GROUPING SETS of 3 sets but %#v", sel.GroupByClause[0])
+				}
+				if s, ok := g.Sets[2].(*sqlast.GroupingSet); !ok || len(s.Exprs) != 0 {
+					t.Errorf("must be empty GroupingSet but %#v", g.Sets[2])
+				}
+				item := sel.Projection[2].(*sqlast.UnnamedSelectItem)
+				if _, ok := item.Node.(*sqlast.Grouping); !ok {
+					t.Errorf("must be Grouping but %T", item.Node)
+				}
+			},
+		},
+		{
+			name: "parenthesized expression",
+			in:   "SELECT a FROM t GROUP BY (a + 1) * 2",
+			check: func(t *testing.T, sel *sqlast.SQLSelect) {
+				if _, ok := sel.GroupByClause[0].(*sqlast.BinaryExpr); !ok {
+					t.Errorf("must be BinaryExpr but %T", sel.GroupByClause[0])
+				}
+			},
+		},
+		{
+			name: "distinct",
+			in:   "SELECT a, b FROM t GROUP BY DISTINCT ROLLUP (a, b), CUBE (a, b)",
+			check: func(t *testing.T, sel *sqlast.SQLSelect) {
+				if !sel.GroupByDistinct {
+					t.Error("must be GROUP BY DISTINCT")
+				}
+			},
+		},
+		{
+			name: "all",
+			in:   "SELECT a FROM t GROUP BY ALL a",
+			check: func(t *testing.T, sel *sqlast.SQLSelect) {
+				if !sel.GroupByAll {
+					t.Error("must be GROUP BY ALL")
+				}
+			},
+		},
+		{
+			name: "with rollup",
+			in:   "SELECT a, b, SUM(c) FROM t GROUP BY a, b WITH ROLLUP",
+			opts: []ParserOption{mysql},
+			check: func(t *testing.T, sel *sqlast.SQLSelect) {
+				if !sel.WithRollup {
+					t.Error("must be WITH ROLLUP")
+				}
+			},
+		},
+		{
+			name: "rollup function in mysql",
+			in:   "SELECT a FROM t GROUP BY rollup(a)",
+			opts: []ParserOption{mysql},
+			check: func(t *testing.T, sel *sqlast.SQLSelect) {
+				if _, ok := sel.GroupByClause[0].(*sqlast.Function); !ok {
+					t.Errorf("must be Function but %T", sel.GroupByClause[0])
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parseRoundTrip(t, c.in, c.opts...)
+			c.check(t, stmt.(*sqlast.QueryStmt).Body.(*sqlast.SQLSelect))
+		})
+	}
+}
+
+func TestParser_OrderByLimit(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		orderBy []*sqlast.OrderByExpr
+		limit   *sqlast.LimitExpr
+	}{
+		{
+			name: "nulls first and last",
+			in:   "SELECT a FROM t ORDER BY a DESC NULLS LAST, b NULLS FIRST",
+			orderBy: []*sqlast.OrderByExpr{
+				{Expr: sqlast.NewIdent("a"), ASC: boolPtr(false), NullsFirst: boolPtr(false)},
+				{Expr: sqlast.NewIdent("b"), NullsFirst: boolPtr(true)},
+			},
+		},
+		{
+			name: "using operator",
+			in:   "SELECT a FROM t ORDER BY a USING >",
+			orderBy: []*sqlast.OrderByExpr{
+				{Expr: sqlast.NewIdent("a"), Using: &sqlast.Operator{Type: sqlast.Gt}},
+			},
+		},
+		{
+			name: "limit expressions",
+			in:   "SELECT a FROM t LIMIT $1 + 1 OFFSET ?",
+			limit: &sqlast.LimitExpr{
+				LimitValue: &sqlast.BinaryExpr{
+					Left:  &sqlast.Placeholder{Style: sqlast.DollarPlaceholder, Index: 1},
+					Op:    &sqlast.Operator{Type: sqlast.Plus},
+					Right: sqlast.NewLongValue(1),
+				},
+				OffsetValue: &sqlast.Placeholder{Style: sqlast.QuestionPlaceholder},
+			},
+		},
+		{
+			name: "limit offset, count",
+			in:   "SELECT a FROM t LIMIT 20, 10",
+			limit: &sqlast.LimitExpr{
+				LimitValue:  sqlast.NewLongValue(10),
+				OffsetValue: sqlast.NewLongValue(20),
+				Syntax:      sqlast.LimitCommaSyntax,
+			},
+		},
+		{
+			name: "offset fetch",
+			in:   "SELECT a FROM t ORDER BY a OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+			orderBy: []*sqlast.OrderByExpr{
+				{Expr: sqlast.NewIdent("a")},
+			},
+			limit: &sqlast.LimitExpr{
+				LimitValue:  sqlast.NewLongValue(10),
+				OffsetValue: sqlast.NewLongValue(20),
+				Syntax:      sqlast.OffsetFetchSyntax,
+				OffsetRows:  "ROWS",
+				FetchFirst:  "NEXT",
+				FetchRows:   "ROWS",
+			},
+		},
+		{
+			name: "fetch first with ties",
+			in:   "SELECT a FROM t ORDER BY a FETCH FIRST ROW WITH TIES",
+			orderBy: []*sqlast.OrderByExpr{
+				{Expr: sqlast.NewIdent("a")},
+			},
+			limit: &sqlast.LimitExpr{
+				Syntax:     sqlast.OffsetFetchSyntax,
+				FetchFirst: "FIRST",
+				FetchRows:  "ROW",
+				WithTies:   true,
+			},
+		},
+		{
+			name: "offset only",
+			in:   "SELECT a FROM t OFFSET 5",
+			limit: &sqlast.LimitExpr{
+				OffsetValue: sqlast.NewLongValue(5),
+				Syntax:      sqlast.OffsetFetchSyntax,
+			},
+		},
+		{
+			name: "limit all offset",
+			in:   "SELECT a FROM t LIMIT ALL OFFSET 3",
+			limit: &sqlast.LimitExpr{
+				All:         true,
+				OffsetValue: sqlast.NewLongValue(3),
+			},
+		},
+		{
+			name: "offset limit",
+			in:   "SELECT a FROM t OFFSET 1 LIMIT 2",
+			limit: &sqlast.LimitExpr{
+				LimitValue:  sqlast.NewLongValue(2),
+				OffsetValue: sqlast.NewLongValue(1),
+				Syntax:      sqlast.OffsetLimitSyntax,
+			},
+		},
+		{
+			name: "offset rows limit all",
+			in:   "SELECT a FROM t OFFSET 1 ROWS LIMIT ALL",
+			limit: &sqlast.LimitExpr{
+				All:         true,
+				OffsetValue: sqlast.NewLongValue(1),
+				Syntax:      sqlast.OffsetLimitSyntax,
+				OffsetRows:  "ROWS",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q := parseRoundTrip(t, c.in).(*sqlast.QueryStmt)
+			if diff := cmp.Diff(c.orderBy, q.OrderBy, ignorePos); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+			if diff := cmp.Diff(c.limit, q.Limit, ignorePos); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+		})
+	}
+}
+
+func TestParser_LockingClause(t *testing.T) {
+	cases := []struct {
+		name  string
+		in    string
+		locks []*sqlast.LockingClause
+	}{
+		{
+			name: "skip locked",
+			in:   "SELECT id FROM jobs WHERE state = 'queued' ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED",
+			locks: []*sqlast.LockingClause{
+				{Strength: sqlast.ForUpdate, Wait: sqlast.LockSkipLocked},
+			},
+		},
+		{
+			name: "multiple clauses with of",
+			in:   "SELECT * FROM a, b FOR NO KEY UPDATE OF a NOWAIT FOR KEY SHARE OF b, public.c",
+			locks: []*sqlast.LockingClause{
+				{Strength: sqlast.ForNoKeyUpdate, Of: []*sqlast.ObjectName{sqlast.NewObjectName("a")}, Wait: sqlast.LockNoWait},
+				{Strength: sqlast.ForKeyShare, Of: []*sqlast.ObjectName{sqlast.NewObjectName("b"), sqlast.NewObjectName("public", "c")}},
+			},
+		},
+		{
+			name: "for share",
+			in:   "SELECT * FROM t FOR SHARE",
+			locks: []*sqlast.LockingClause{
+				{Strength: sqlast.ForShare},
+			},
+		},
+		{
+			name: "before limit",
+			in:   "SELECT * FROM t ORDER BY id FOR UPDATE SKIP LOCKED LIMIT 1 OFFSET 2",
+			locks: []*sqlast.LockingClause{
+				{Strength: sqlast.ForUpdate, Wait: sqlast.LockSkipLocked},
+			},
+		},
+		{
+			name: "lock in share mode",
+			in:   "SELECT * FROM t LOCK IN SHARE MODE",
+			locks: []*sqlast.LockingClause{
+				{Strength: sqlast.LockInShareMode},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q := parseRoundTrip(t, c.in).(*sqlast.QueryStmt)
+			if diff := cmp.Diff(c.locks, q.Locks, ignorePos); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+			if !q.IsLocking() {
+				t.Error("must be locking")
+			}
+		})
+	}
+}
+
+func TestParser_SelectDistinct(t *testing.T) {
+	cases := []struct {
+		name     string
+		in       string
+		distinct bool
+		clause   *sqlast.SelectDistinct
+	}{
+		{
+			name: "none",
+			in:   "SELECT a FROM t",
+		},
+		{
+			name:   "all",
+			in:     "SELECT ALL a FROM t",
+			clause: &sqlast.SelectDistinct{Type: sqlast.SelectAll},
+		},
+		{
+			name:     "distinct",
+			in:       "SELECT DISTINCT a FROM t",
+			distinct: true,
+			clause:   &sqlast.SelectDistinct{Type: sqlast.SelectDistinctRows},
+		},
+		{
+			name:     "distinct on",
+			in:       "SELECT DISTINCT ON (user_id, date_trunc('day', at)) user_id, at FROM events ORDER BY user_id, at DESC",
+			distinct: true,
+			clause: &sqlast.SelectDistinct{
+				Type: sqlast.SelectDistinctOn,
+				On: []sqlast.Node{
+					sqlast.NewIdent("user_id"),
+					&sqlast.Function{
+						Name: sqlast.NewObjectName("date_trunc"),
+						Args: []sqlast.Node{sqlast.NewSingleQuotedString("day"), sqlast.NewIdent("at")},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parseRoundTrip(t, c.in, Dialect(&dialect.PostgresqlDialect{}))
+			sel := stmt.(*sqlast.QueryStmt).Body.(*sqlast.SQLSelect)
+			if sel.Distinct != c.distinct {
+				t.Errorf("Distinct must be %v but %v", c.distinct, sel.Distinct)
+			}
+			if diff := cmp.Diff(c.clause, sel.DistinctClause, ignorePos); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+		})
+	}
+}
+
+func TestParser_CTE(t *testing.T) {
+	cases := []struct {
+		name      string
+		in        string
+		recursive bool
+		ctes      []*sqlast.CTE
+	}{
+		{
+			name:      "recursive with columns",
+			in:        "WITH RECURSIVE t (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM t WHERE n < 100) SELECT sum(n) FROM t",
+			recursive: true,
+			ctes: []*sqlast.CTE{
+				{Alias: sqlast.NewIdent("t"), Columns: []*sqlast.Ident{sqlast.NewIdent("n")}},
+			},
+		},
+		{
+			name: "materialized",
+			in:   "WITH a AS MATERIALIZED (SELECT x FROM s), b AS NOT MATERIALIZED (SELECT y FROM u) SELECT * FROM a, b",
+			ctes: []*sqlast.CTE{
+				{Alias: sqlast.NewIdent("a"), Materialized: boolPtr(true)},
+				{Alias: sqlast.NewIdent("b"), Materialized: boolPtr(false)},
+			},
+		},
+		{
+			name:      "search and cycle",
+			in:        "WITH RECURSIVE tree (id, parent) AS (SELECT id, parent FROM nodes) SEARCH DEPTH FIRST BY id SET ordercol CYCLE id SET is_cycle TO true DEFAULT false USING path SELECT * FROM tree ORDER BY ordercol",
+			recursive: true,
+			ctes: []*sqlast.CTE{
+				{
+					Alias:   sqlast.NewIdent("tree"),
+					Columns: []*sqlast.Ident{sqlast.NewIdent("id"), sqlast.NewIdent("parent")},
+					Search: &sqlast.CTESearch{
+						Columns: []*sqlast.Ident{sqlast.NewIdent("id")},
+						Set:     sqlast.NewIdent("ordercol"),
+					},
+					Cycle: &sqlast.CTECycle{
+						Columns: []*sqlast.Ident{sqlast.NewIdent("id")},
+						Set:     sqlast.NewIdent("is_cycle"),
+						To:      sqlast.NewBooleanValue(true),
+						Default: sqlast.NewBooleanValue(false),
+						Using:   sqlast.NewIdent("path"),
+					},
+				},
+			},
+		},
+		{
+			name:      "breadth first",
+			in:        "WITH RECURSIVE tree AS (SELECT id FROM nodes) SEARCH BREADTH FIRST BY id SET ordercol SELECT * FROM tree",
+			recursive: true,
+			ctes: []*sqlast.CTE{
+				{
+					Alias: sqlast.NewIdent("tree"),
+					Search: &sqlast.CTESearch{
+						Breadth: true,
+						Columns: []*sqlast.Ident{sqlast.NewIdent("id")},
+						Set:     sqlast.NewIdent("ordercol"),
+					},
+				},
+			},
+		},
+		{
+			name: "data-modifying",
+			in:   "WITH moved AS (DELETE FROM products WHERE date < '2010-11-01') INSERT INTO products_log SELECT * FROM moved",
+			ctes: []*sqlast.CTE{
+				{Alias: sqlast.NewIdent("moved")},
+			},
+		},
+		{
+			name: "update",
+			in:   "WITH t AS (SELECT id FROM s) UPDATE u SET a = 1 WHERE id IN (SELECT id FROM t)",
+			ctes: []*sqlast.CTE{
+				{Alias: sqlast.NewIdent("t")},
+			},
+		},
+		{
+			name: "delete",
+			in:   "WITH t AS (SELECT id FROM s) DELETE FROM u WHERE id IN (SELECT id FROM t)",
+			ctes: []*sqlast.CTE{
+				{Alias: sqlast.NewIdent("t")},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parseRoundTrip(t, c.in, Dialect(&dialect.PostgresqlDialect{}))
+
+			var recursive bool
+			var ctes []*sqlast.CTE
+			switch s := stmt.(type) {
+			case *sqlast.QueryStmt:
+				recursive, ctes = s.Recursive, s.CTEs
+			case *sqlast.InsertStmt:
+				recursive, ctes = s.Recursive, s.CTEs
+			case *sqlast.UpdateStmt:
+				recursive, ctes = s.Recursive, s.CTEs
+			case *sqlast.DeleteStmt:
+				recursive, ctes = s.Recursive, s.CTEs
+			default:
+				t.Fatalf("unexpected statement %T", stmt)
+			}
+
+			if recursive != c.recursive {
+				t.Errorf("Recursive must be %v but %v", c.recursive, recursive)
+			}
+			// bodies are checked by ToSQLString
+			if diff := cmp.Diff(c.ctes, ctes, ignorePos, cmpopts.IgnoreFields(sqlast.CTE{}, "Query", "DML")); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+			if act := stmt.Pos(); act != sqltoken.NewPos(1, 1) {
+				t.Errorf("must start at %+v but %+v", sqltoken.NewPos(1, 1), act)
+			}
+		})
+	}
+}
+
+func TestParser_Values(t *testing.T) {
+	cases := []struct {
+		name  string
+		in    string
+		check func(t *testing.T, q *sqlast.QueryStmt)
+	}{
+		{
+			name: "standalone",
+			in:   "VALUES (1, 'a'), (2, 'b') ORDER BY 1 LIMIT 1",
+			check: func(t *testing.T, q *sqlast.QueryStmt) {
+				v, ok := q.Body.(*sqlast.ConstructorSource)
+				if !ok {
+					t.Fatalf("must be ConstructorSource but %T", q.Body)
+				}
+				if len(v.Rows) != 2 {
+					t.Errorf("must have 2 rows but %d", len(v.Rows))
+				}
+			},
+		},
+		{
+			name: "union",
+			in:   "SELECT a, b FROM t UNION ALL VALUES (1, 'a')",
+			check: func(t *testing.T, q *sqlast.QueryStmt) {
+				s, ok := q.Body.(*sqlast.SetOperationExpr)
+				if !ok {
+					t.Fatalf("must be SetOperationExpr but %T", q.Body)
+				}
+				if _, ok := s.Right.(*sqlast.ConstructorSource); !ok {
+					t.Errorf("must be ConstructorSource but %T", s.Right)
+				}
+			},
+		},
+		{
+			name: "derived table with column aliases",
+			in:   "SELECT id, name FROM (VALUES (1, 'a'), (2, 'b')) AS t (id, name)",
+			check: func(t *testing.T, q *sqlast.QueryStmt) {
+				d, ok := q.Body.(*sqlast.SQLSelect).FromClause[0].(*sqlast.Derived)
+				if !ok {
+					t.Fatalf("must be Derived but %T", q.Body.(*sqlast.SQLSelect).FromClause[0])
+				}
+				if _, ok := d.SubQuery.Body.(*sqlast.ConstructorSource); !ok {
+					t.Errorf("must be ConstructorSource but %T", d.SubQuery.Body)
+				}
+				if diff := cmp.Diff([]*sqlast.Ident{sqlast.NewIdent("id"), sqlast.NewIdent("name")}, d.Columns, ignorePos); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			},
+		},
+		{
+			name: "in subquery",
+			in:   "SELECT a FROM t WHERE a IN (VALUES (1), (2))",
+			check: func(t *testing.T, q *sqlast.QueryStmt) {
+				in, ok := q.Body.(*sqlast.SQLSelect).WhereClause.(*sqlast.InSubQuery)
+				if !ok {
+					t.Fatalf("must be InSubQuery but %T", q.Body.(*sqlast.SQLSelect).WhereClause)
+				}
+				if _, ok := in.SubQuery.Body.(*sqlast.ConstructorSource); !ok {
+					t.Errorf("must be ConstructorSource but %T", in.SubQuery.Body)
+				}
+			},
+		},
+		{
+			name: "table with column aliases",
+			in:   "SELECT n FROM t AS u (n)",
+			check: func(t *testing.T, q *sqlast.QueryStmt) {
+				tbl := q.Body.(*sqlast.SQLSelect).FromClause[0].(*sqlast.Table)
+				if diff := cmp.Diff([]*sqlast.Ident{sqlast.NewIdent("n")}, tbl.Columns, ignorePos); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parseRoundTrip(t, c.in)
+			c.check(t, stmt.(*sqlast.QueryStmt))
+		})
+	}
+}
+
+func TestParser_TableFunction(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  *sqlast.TableFunction
+	}{
+		{
+			name: "function",
+			in:   "SELECT n FROM generate_series(1, 3) AS g (n)",
+			out: &sqlast.TableFunction{
+				Functions: []*sqlast.Function{
+					{Name: sqlast.NewObjectName("generate_series"), Args: []sqlast.Node{sqlast.NewLongValue(1), sqlast.NewLongValue(3)}},
+				},
+				Alias:   sqlast.NewIdent("g"),
+				Columns: []*sqlast.Ident{sqlast.NewIdent("n")},
+			},
+		},
+		{
+			name: "with ordinality",
+			in:   "SELECT x, n FROM unnest(arr) WITH ORDINALITY AS u (x, n)",
+			out: &sqlast.TableFunction{
+				Functions: []*sqlast.Function{
+					{Name: sqlast.NewObjectName("unnest"), Args: []sqlast.Node{sqlast.NewIdent("arr")}},
+				},
+				WithOrdinality: true,
+				Alias:          sqlast.NewIdent("u"),
+				Columns:        []*sqlast.Ident{sqlast.NewIdent("x"), sqlast.NewIdent("n")},
+			},
+		},
+		{
+			name: "lateral with ordinality",
+			in:   "SELECT * FROM t, LATERAL unnest(t.tags) WITH ORDINALITY",
+			out: &sqlast.TableFunction{
+				Lateral: true,
+				Functions: []*sqlast.Function{
+					{Name: sqlast.NewObjectName("unnest"), Args: []sqlast.Node{&sqlast.CompoundIdent{Idents: []*sqlast.Ident{sqlast.NewIdent("t"), sqlast.NewIdent("tags")}}}},
+				},
+				WithOrdinality: true,
+			},
+		},
+		{
+			name: "rows from",
+			in:   "SELECT * FROM ROWS FROM (generate_series(1, 2), unnest(arr)) AS r (a, b)",
+			out: &sqlast.TableFunction{
+				RowsFrom: true,
+				Functions: []*sqlast.Function{
+					{Name: sqlast.NewObjectName("generate_series"), Args: []sqlast.Node{sqlast.NewLongValue(1), sqlast.NewLongValue(2)}},
+					{Name: sqlast.NewObjectName("unnest"), Args: []sqlast.Node{sqlast.NewIdent("arr")}},
+				},
+				Alias:   sqlast.NewIdent("r"),
+				Columns: []*sqlast.Ident{sqlast.NewIdent("a"), sqlast.NewIdent("b")},
+			},
+		},
+		{
+			name: "column definition list",
+			in:   "SELECT * FROM dblink('dbname=mydb', 'SELECT proname FROM pg_proc') AS t (proname name, n int)",
+			out: &sqlast.TableFunction{
+				Functions: []*sqlast.Function{
+					{
+						Name: sqlast.NewObjectName("dblink"),
+						Args: []sqlast.Node{
+							sqlast.NewSingleQuotedString("dbname=mydb"),
+							sqlast.NewSingleQuotedString("SELECT proname FROM pg_proc"),
+						},
+					},
+				},
+				Alias: sqlast.NewIdent("t"),
+				ColumnDefs: []*sqlast.TableFunctionColumn{
+					{Name: sqlast.NewIdent("proname"), DataType: &sqlast.Custom{Ty: sqlast.NewObjectName("name")}},
+					{Name: sqlast.NewIdent("n"), DataType: &sqlast.Int{}},
+				},
+			},
+		},
+		{
+			name: "column definition list without alias",
+			in:   "SELECT * FROM json_to_record(j) AS (a int, b text)",
+			out: &sqlast.TableFunction{
+				Functions: []*sqlast.Function{
+					{Name: sqlast.NewObjectName("json_to_record"), Args: []sqlast.Node{sqlast.NewIdent("j")}},
+				},
+				ColumnDefs: []*sqlast.TableFunctionColumn{
+					{Name: sqlast.NewIdent("a"), DataType: &sqlast.Int{}},
+					{Name: sqlast.NewIdent("b"), DataType: &sqlast.Text{}},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parseRoundTrip(t, c.in, Dialect(&dialect.PostgresqlDialect{}))
+			from := stmt.(*sqlast.QueryStmt).Body.(*sqlast.SQLSelect).FromClause
+			if diff := cmp.Diff(c.out, from[len(from)-1], ignorePos); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+		})
+	}
+}
+
+func TestParser_TableSampleAndSystemTime(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  *sqlast.Table
+	}{
+		{
+			name: "tablesample",
+			in:   "SELECT * FROM events AS e TABLESAMPLE BERNOULLI (10) REPEATABLE (42)",
+			out: &sqlast.Table{
+				Name:  sqlast.NewObjectName("events"),
+				Alias: sqlast.NewIdent("e"),
+				Sample: &sqlast.TableSample{
+					Method: sqlast.NewIdent("BERNOULLI"),
+					Args:   []sqlast.Node{sqlast.NewLongValue(10)},
+					Seed:   sqlast.NewLongValue(42),
+				},
+			},
+		},
+		{
+			name: "tablesample percent",
+			in:   "SELECT * FROM events TABLESAMPLE (10 PERCENT)",
+			out: &sqlast.Table{
+				Name: sqlast.NewObjectName("events"),
+				Sample: &sqlast.TableSample{
+					Args: []sqlast.Node{sqlast.NewLongValue(10)},
+					Unit: sqlast.SamplePercent,
+				},
+			},
+		},
+		{
+			name: "system_time as of",
+			in:   "SELECT * FROM employees FOR SYSTEM_TIME AS OF '2021-01-01' AS e",
+			out: &sqlast.Table{
+				Name: sqlast.NewObjectName("employees"),
+				SystemTime: &sqlast.SystemTime{
+					Type:  sqlast.SystemTimeAsOf,
+					Value: sqlast.NewSingleQuotedString("2021-01-01"),
+				},
+				Alias: sqlast.NewIdent("e"),
+			},
+		},
+		{
+			name: "system_time between",
+			in:   "SELECT * FROM employees FOR SYSTEM_TIME BETWEEN @start AND @end",
+			out: &sqlast.Table{
+				Name: sqlast.NewObjectName("employees"),
+				SystemTime: &sqlast.SystemTime{
+					Type:  sqlast.SystemTimeBetween,
+					Start: &sqlast.Placeholder{Style: sqlast.AtPlaceholder, Name: "start"},
+					Stop:  &sqlast.Placeholder{Style: sqlast.AtPlaceholder, Name: "end"},
+				},
+			},
+		},
+		{
+			name: "system_time from to",
+			in:   "SELECT * FROM employees FOR SYSTEM_TIME FROM '2020-01-01' TO '2021-01-01'",
+			out: &sqlast.Table{
+				Name: sqlast.NewObjectName("employees"),
+				SystemTime: &sqlast.SystemTime{
+					Type:  sqlast.SystemTimeFromTo,
+					Start: sqlast.NewSingleQuotedString("2020-01-01"),
+					Stop:  sqlast.NewSingleQuotedString("2021-01-01"),
+				},
+			},
+		},
+		{
+			name: "system_time contained in",
+			in:   "SELECT * FROM employees FOR SYSTEM_TIME CONTAINED IN ('2020-01-01', '2021-01-01')",
+			out: &sqlast.Table{
+				Name: sqlast.NewObjectName("employees"),
+				SystemTime: &sqlast.SystemTime{
+					Type:  sqlast.SystemTimeContainedIn,
+					Start: sqlast.NewSingleQuotedString("2020-01-01"),
+					Stop:  sqlast.NewSingleQuotedString("2021-01-01"),
+				},
+			},
+		},
+		{
+			name: "system_time all",
+			in:   "SELECT * FROM employees FOR SYSTEM_TIME ALL WHERE id = 1",
+			out: &sqlast.Table{
+				Name: sqlast.NewObjectName("employees"),
+				SystemTime: &sqlast.SystemTime{
+					Type: sqlast.SystemTimeAll,
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parseRoundTrip(t, c.in)
+			if diff := cmp.Diff(c.out, stmt.(*sqlast.QueryStmt).Body.(*sqlast.SQLSelect).FromClause[0], ignorePos); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+		})
+	}
+}
+
+func TestParser_OnConflictAndReturning(t *testing.T) {
+	cases := []struct {
+		name  string
+		in    string
+		check func(t *testing.T, stmt sqlast.Stmt)
+	}{
+		{
+			name: "do nothing",
+			in:   "INSERT INTO t (id, name) VALUES (1, 'a') ON CONFLICT DO NOTHING",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				c := stmt.(*sqlast.InsertStmt).OnConflict
+				if c == nil || !c.DoNothing || len(c.Target) != 0 {
+					t.Errorf("must be ON CONFLICT DO NOTHING but %#v", c)
+				}
+			},
+		},
+		{
+			name: "do update with excluded",
+			in:   "INSERT INTO t (id, name) VALUES (1, 'a') ON CONFLICT (id) WHERE deleted_at IS NULL DO UPDATE SET name = EXCLUDED.name WHERE t.name != EXCLUDED.name RETURNING id, name AS new_name",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				i := stmt.(*sqlast.InsertStmt)
+				exp := &sqlast.OnConflict{
+					Target:      []sqlast.Node{sqlast.NewIdent("id")},
+					TargetWhere: &sqlast.IsNull{X: sqlast.NewIdent("deleted_at")},
+					Assignments: []*sqlast.Assignment{
+						{
+							ID:    sqlast.NewIdent("name"),
+							Value: &sqlast.CompoundIdent{Idents: []*sqlast.Ident{sqlast.NewIdent("EXCLUDED"), sqlast.NewIdent("name")}},
+						},
+					},
+					Selection: &sqlast.BinaryExpr{
+						Left:  &sqlast.CompoundIdent{Idents: []*sqlast.Ident{sqlast.NewIdent("t"), sqlast.NewIdent("name")}},
+						Op:    &sqlast.Operator{Type: sqlast.NotEq},
+						Right: &sqlast.CompoundIdent{Idents: []*sqlast.Ident{sqlast.NewIdent("EXCLUDED"), sqlast.NewIdent("name")}},
+					},
+				}
+				if diff := cmp.Diff(exp, i.OnConflict, ignorePos); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+				if i.Returning == nil || len(i.Returning.Items) != 2 {
+					t.Errorf("must have RETURNING with 2 items but %#v", i.Returning)
+				}
+			},
+		},
+		{
+			name: "on constraint",
+			in:   "INSERT INTO t (id) VALUES (1) ON CONFLICT ON CONSTRAINT t_pkey DO NOTHING",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				c := stmt.(*sqlast.InsertStmt).OnConflict
+				if c == nil || c.Constraint == nil || c.Constraint.Value != "t_pkey" {
+					t.Errorf("must be ON CONSTRAINT t_pkey but %#v", c)
+				}
+			},
+		},
+		{
+			name: "aliased target",
+			in:   "INSERT INTO t AS x (a) VALUES (1) ON CONFLICT (a) DO UPDATE SET a = x.a + 1",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				i := stmt.(*sqlast.InsertStmt)
+				if i.Alias == nil || i.Alias.Value != "x" {
+					t.Errorf("must have alias x but %#v", i.Alias)
+				}
+				if len(i.Columns) != 1 || i.OnConflict == nil {
+					t.Errorf("must have columns and ON CONFLICT but %s", i.ToSQLString())
+				}
+			},
+		},
+		{
+			name: "do update with column list",
+			in:   "INSERT INTO t (a, b) VALUES (1, 2) ON CONFLICT (a) DO UPDATE SET (a, b) = (EXCLUDED.a, DEFAULT), c = 3",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				as := stmt.(*sqlast.InsertStmt).OnConflict.Assignments
+				exp := []*sqlast.Assignment{
+					{
+						Columns: []*sqlast.Ident{sqlast.NewIdent("a"), sqlast.NewIdent("b")},
+						Value: &sqlast.RowValueExpr{
+							Values: []sqlast.Node{
+								&sqlast.CompoundIdent{Idents: []*sqlast.Ident{sqlast.NewIdent("EXCLUDED"), sqlast.NewIdent("a")}},
+								&sqlast.DefaultValue{},
+							},
+						},
+					},
+					{ID: sqlast.NewIdent("c"), Value: sqlast.NewLongValue(3)},
+				}
+				if diff := cmp.Diff(exp, as, ignorePos); diff != "" {
+					t.Errorf("diff %s", diff)
+				}
+			},
+		},
+		{
+			name: "update column list from sub query",
+			in:   "UPDATE t SET (a, b) = (SELECT x, y FROM s WHERE s.id = t.id) WHERE id = 1",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				a := stmt.(*sqlast.UpdateStmt).Assignments[0]
+				if len(a.Columns) != 2 || a.ID != nil {
+					t.Errorf("must have 2 columns but %s", a.ToSQLString())
+				}
+				if _, ok := a.Value.(*sqlast.SubQuery); !ok {
+					t.Errorf("must be SubQuery but %T", a.Value)
+				}
+			},
+		},
+		{
+			name: "update returning",
+			in:   "UPDATE t SET a = a + 1 WHERE id = 1 RETURNING *",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				if stmt.(*sqlast.UpdateStmt).Returning == nil {
+					t.Error("must have RETURNING")
+				}
+			},
+		},
+		{
+			name: "delete returning",
+			in:   "DELETE FROM t WHERE id = 1 RETURNING id",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				if stmt.(*sqlast.DeleteStmt).Returning == nil {
+					t.Error("must have RETURNING")
+				}
+			},
+		},
+		{
+			name: "insert select returning",
+			in:   "INSERT INTO t SELECT * FROM s RETURNING id",
+			check: func(t *testing.T, stmt sqlast.Stmt) {
+				i := stmt.(*sqlast.InsertStmt)
+				if i.Returning == nil {
+					t.Error("must have RETURNING")
+				}
+				sel := i.Source.(*sqlast.SubQuerySource).SubQuery.Body.(*sqlast.SQLSelect)
+				if a := sel.FromClause[0].(*sqlast.Table).Alias; a != nil {
+					t.Errorf("must not have alias but %s", a.ToSQLString())
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parseRoundTrip(t, c.in, Dialect(&dialect.PostgresqlDialect{}))
+			c.check(t, stmt)
+		})
+	}
+}
+
+func TestParser_InsertVariants(t *testing.T) {
+	mysql := Dialect(&dialect.MySQLDialect{})
+	pg := Dialect(&dialect.PostgresqlDialect{})
+
+	cases := []struct {
+		name  string
+		in    string
+		opts  []ParserOption
+		check func(t *testing.T, i *sqlast.InsertStmt)
+	}{
+		{
+			name: "default values",
+			in:   "INSERT INTO t DEFAULT VALUES RETURNING id",
+			opts: []ParserOption{pg},
+			check: func(t *testing.T, i *sqlast.InsertStmt) {
+				if _, ok := i.Source.(*sqlast.DefaultValuesSource); !ok {
+					t.Errorf("must be DefaultValuesSource but %T", i.Source)
+				}
+			},
+		},
+		{
+			name: "default in rows",
+			in:   "INSERT INTO t (id, name) VALUES (DEFAULT, 'a'), (2, DEFAULT)",
+			opts: []ParserOption{pg},
+			check: func(t *testing.T, i *sqlast.InsertStmt) {
+				rows := i.Source.(*sqlast.ConstructorSource).Rows
+				if _, ok := rows[0].Values[0].(*sqlast.DefaultValue); !ok {
+					t.Errorf("must be DefaultValue but %T", rows[0].Values[0])
+				}
+				if _, ok := rows[1].Values[1].(*sqlast.DefaultValue); !ok {
+					t.Errorf("must be DefaultValue but %T", rows[1].Values[1])
+				}
+			},
+		},
+		{
+			name: "insert ignore",
+			in:   "INSERT IGNORE INTO t (a) VALUES (1)",
+			opts: []ParserOption{mysql},
+			check: func(t *testing.T, i *sqlast.InsertStmt) {
+				if !i.Ignore {
+					t.Error("must be INSERT IGNORE")
+				}
+			},
+		},
+		{
+			name: "replace into",
+			in:   "REPLACE INTO t (a) VALUES (1)",
+			opts: []ParserOption{mysql},
+			check: func(t *testing.T, i *sqlast.InsertStmt) {
+				if !i.Replace {
+					t.Error("must be REPLACE INTO")
+				}
+			},
+		},
+		{
+			name: "insert set",
+			in:   "INSERT INTO t SET a = 1, b = DEFAULT ON DUPLICATE KEY UPDATE a = 2",
+			opts: []ParserOption{mysql},
+			check: func(t *testing.T, i *sqlast.InsertStmt) {
+				s, ok := i.Source.(*sqlast.SetSource)
+				if !ok {
+					t.Fatalf("must be SetSource but %T", i.Source)
+				}
+				if _, ok := s.Assignments[1].Value.(*sqlast.DefaultValue); !ok {
+					t.Errorf("must be DefaultValue but %T", s.Assignments[1].Value)
+				}
+			},
+		},
+		{
+			name: "parenthesized query with cte",
+			in:   "INSERT INTO t (WITH x AS (SELECT a FROM s) SELECT a FROM x)",
+			check: func(t *testing.T, i *sqlast.InsertStmt) {
+				if len(i.Columns) != 0 {
+					t.Errorf("must not have columns but %d", len(i.Columns))
+				}
+				q := i.Source.(*sqlast.SubQuerySource).SubQuery
+				if e, ok := q.Body.(*sqlast.QueryExpr); !ok || len(e.Query.CTEs) != 1 {
+					t.Errorf("must be parenthesized query with CTE but %#v", q.Body)
+				}
+			},
+		},
+		{
+			name: "with prefixed insert",
+			in:   "WITH x AS (SELECT a FROM s) INSERT INTO t (a) SELECT a FROM x",
+			check: func(t *testing.T, i *sqlast.InsertStmt) {
+				if len(i.CTEs) != 1 {
+					t.Errorf("must have 1 CTE but %d", len(i.CTEs))
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt := parseRoundTrip(t, c.in, c.opts...)
+			c.check(t, stmt.(*sqlast.InsertStmt))
+		})
+	}
+}
+
+func TestParseDataTypeString(t *testing.T) {
+	tp, err := ParseDataTypeString("varchar(255)")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, ok := tp.(*sqlast.VarcharType); !ok {
+		t.Errorf("must be VarcharType but %T", tp)
+	}
+}
+
+func TestParse_Dialects(t *testing.T) {
+	mysql := Dialect(&dialect.MySQLDialect{})
+	pg := Dialect(&dialect.PostgresqlDialect{})
+	sqlite := Dialect(&dialect.SQLiteDialect{})
+	mssql := Dialect(&dialect.MSSQLDialect{})
+
+	cases := []struct {
+		name string
+		in   string
+		opts []ParserOption
+		err  bool
+	}{
+		{
+			name: "on duplicate key update in mysql",
+			in:   "INSERT INTO t (a) VALUES (1) ON DUPLICATE KEY UPDATE a = 2",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "on duplicate key update in postgresql",
+			in:   "INSERT INTO t (a) VALUES (1) ON DUPLICATE KEY UPDATE a = 2",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "double colon cast in postgresql",
+			in:   "SELECT a::text FROM t",
+			opts: []ParserOption{pg},
+		},
+		{
+			name: "double colon cast in mysql",
+			in:   "SELECT a::text FROM t",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "backtick in postgresql",
+			in:   "SELECT `a` FROM t",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "unsigned in postgresql",
+			in:   "CREATE TABLE t (a int UNSIGNED)",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "auto_increment in mysql",
+			in:   "CREATE TABLE t (a int AUTO_INCREMENT) ENGINE=InnoDB",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "auto_increment in postgresql",
+			in:   "CREATE TABLE t (a int AUTO_INCREMENT)",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "table options in postgresql",
+			in:   "CREATE TABLE t (a int) ENGINE=InnoDB",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "insert or replace in sqlite",
+			in:   "INSERT OR REPLACE INTO t (a) VALUES (1)",
+			opts: []ParserOption{sqlite},
+		},
+		{
+			name: "insert or replace in mysql",
+			in:   "INSERT OR REPLACE INTO t (a) VALUES (1)",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "replace into in postgresql",
+			in:   "REPLACE INTO t (a) VALUES (1)",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "pragma in postgresql",
+			in:   "PRAGMA foreign_keys = ON",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "without rowid in mysql",
+			in:   "CREATE TABLE t (a int) WITHOUT ROWID",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "engine in sqlite",
+			in:   "CREATE TABLE t (a int) ENGINE=InnoDB",
+			opts: []ParserOption{sqlite},
+			err:  true,
+		},
+		{
+			name: "cross apply in sqlserver",
+			in:   "SELECT TOP (1) * FROM t WITH (NOLOCK) CROSS APPLY f(t.id)",
+			opts: []ParserOption{mssql},
+		},
+		{
+			name: "cross apply in postgresql",
+			in:   "SELECT * FROM t CROSS APPLY f(t.id)",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "output in mysql",
+			in:   "DELETE FROM t OUTPUT deleted.* WHERE a = 1",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "bracket in postgresql",
+			in:   "SELECT [a] FROM t",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "with rollup in mysql",
+			in:   "SELECT a, SUM(b) FROM t GROUP BY a WITH ROLLUP",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "with rollup in postgresql",
+			in:   "SELECT a, SUM(b) FROM t GROUP BY a WITH ROLLUP",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "grouping sets in sqlite",
+			in:   "SELECT a, SUM(b) FROM t GROUP BY GROUPING SETS ((a), ())",
+			opts: []ParserOption{sqlite},
+			err:  true,
+		},
+		{
+			name: "limit comma in sqlite",
+			in:   "SELECT a FROM t LIMIT 10, 5",
+			opts: []ParserOption{sqlite},
+		},
+		{
+			name: "limit comma in postgresql",
+			in:   "SELECT a FROM t LIMIT 10, 5",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "offset fetch in mssql",
+			in:   "SELECT a FROM t ORDER BY a OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY",
+			opts: []ParserOption{mssql},
+		},
+		{
+			name: "offset fetch in mysql",
+			in:   "SELECT a FROM t ORDER BY a OFFSET 10 ROWS FETCH NEXT 5 ROWS ONLY",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "offset as aliases in mysql",
+			in:   "SELECT a offset FROM t offset",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "tablesample as alias in mysql",
+			in:   "SELECT * FROM t tablesample",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "numbered question in postgresql",
+			in:   "SELECT a FROM t WHERE b = ?1",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "dollar name in postgresql",
+			in:   "SELECT a FROM t WHERE b = $name",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "column list assignment in mysql",
+			in:   "UPDATE t SET (a, b) = (1, 2)",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "separator in postgresql",
+			in:   "SELECT string_agg(a SEPARATOR ';') FROM t",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "separator in mysql",
+			in:   "SELECT GROUP_CONCAT(a SEPARATOR ';') FROM t",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "filter in mysql",
+			in:   "SELECT count(*) FILTER (WHERE a > 1) FROM t",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "filter in sqlite",
+			in:   "SELECT count(*) FILTER (WHERE a > 1) FROM t",
+			opts: []ParserOption{sqlite},
+		},
+		{
+			name: "filter as alias in mysql",
+			in:   "SELECT count(*) filter FROM t",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "ilike as alias in mysql",
+			in:   "SELECT a ilike FROM t",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "regexp as alias in postgresql",
+			in:   "SELECT a regexp FROM t",
+			opts: []ParserOption{pg},
+		},
+		{
+			name: "interval of expressions in mysql",
+			in:   "SELECT DATE_ADD(d, INTERVAL t.days DAY), d + INTERVAL n DAY, d - INTERVAL -n HOUR AS x FROM t",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "interval as column in mysql",
+			in:   "SELECT interval, interval + 1 FROM t",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "mysql interval unit in postgresql",
+			in:   "SELECT a FROM t WHERE d > now() - INTERVAL '1' quarter",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "returning as aliases in mysql",
+			in:   "SELECT a returning FROM t returning",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "fetch as aliases in sqlite",
+			in:   "SELECT a fetch FROM t fetch",
+			opts: []ParserOption{sqlite},
+		},
+		{
+			name: "offset as alias in postgresql",
+			in:   "SELECT a offset FROM t",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "alias of insert target in mysql",
+			in:   "INSERT INTO t AS x (a) VALUES (1)",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "alias of insert target in sqlite",
+			in:   "INSERT INTO t AS x (a) VALUES (1) ON CONFLICT (a) DO UPDATE SET a = x.a + 1",
+			opts: []ParserOption{sqlite},
+		},
+		{
+			name: "order by using in mysql",
+			in:   "SELECT a FROM t ORDER BY a USING <",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "lock in share mode in mysql",
+			in:   "SELECT a FROM t WHERE id = 1 LOCK IN SHARE MODE",
+			opts: []ParserOption{mysql},
+		},
+		{
+			name: "lock in share mode in postgresql",
+			in:   "SELECT a FROM t WHERE id = 1 LOCK IN SHARE MODE",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "for update in sqlite",
+			in:   "SELECT a FROM t FOR UPDATE",
+			opts: []ParserOption{sqlite},
+			err:  true,
+		},
+		{
+			name: "distinct on in mysql",
+			in:   "SELECT DISTINCT ON (a) a, b FROM t",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "materialized cte in mysql",
+			in:   "WITH t AS MATERIALIZED (SELECT a FROM s) SELECT * FROM t",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "materialized cte in sqlite",
+			in:   "WITH t AS NOT MATERIALIZED (SELECT a FROM s) SELECT * FROM t",
+			opts: []ParserOption{sqlite},
+		},
+		{
+			name: "data-modifying cte in sqlite",
+			in:   "WITH t AS (DELETE FROM s) SELECT * FROM t",
+			opts: []ParserOption{sqlite},
+			err:  true,
+		},
+		{
+			name: "cte search in sqlite",
+			in:   "WITH RECURSIVE t AS (SELECT a FROM s) SEARCH DEPTH FIRST BY a SET o SELECT * FROM t",
+			opts: []ParserOption{sqlite},
+			err:  true,
+		},
+		{
+			name: "with ordinality in mysql",
+			in:   "SELECT * FROM unnest(a) WITH ORDINALITY",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "column definition list in sqlite",
+			in:   "SELECT * FROM json_each(j) AS t (k text)",
+			opts: []ParserOption{sqlite},
+			err:  true,
+		},
+		{
+			name: "table function in sqlite",
+			in:   "SELECT * FROM json_each(j) AS t",
+			opts: []ParserOption{sqlite},
+		},
+		{
+			name: "tablesample in mysql",
+			in:   "SELECT * FROM t TABLESAMPLE SYSTEM (10)",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "for system_time in postgresql",
+			in:   "SELECT * FROM t FOR SYSTEM_TIME AS OF '2021-01-01'",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "for system_time in mssql",
+			in:   "SELECT * FROM t FOR SYSTEM_TIME AS OF '2021-01-01'",
+			opts: []ParserOption{mssql},
+		},
+		{
+			name: "on conflict in mysql",
+			in:   "INSERT INTO t (a) VALUES (1) ON CONFLICT DO NOTHING",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "on conflict in sqlite",
+			in:   "INSERT INTO t (a) VALUES (1) ON CONFLICT (a) DO UPDATE SET a = excluded.a",
+			opts: []ParserOption{sqlite},
+		},
+		{
+			name: "returning in mssql",
+			in:   "DELETE FROM t RETURNING a",
+			opts: []ParserOption{mssql},
+			err:  true,
+		},
+		{
+			name: "insert ignore in postgresql",
+			in:   "INSERT IGNORE INTO t (a) VALUES (1)",
+			opts: []ParserOption{pg},
+			err:  true,
+		},
+		{
+			name: "insert set in sqlite",
+			in:   "INSERT INTO t SET a = 1",
+			opts: []ParserOption{sqlite},
+			err:  true,
+		},
+		{
+			name: "default values in mysql",
+			in:   "INSERT INTO t DEFAULT VALUES",
+			opts: []ParserOption{mysql},
+			err:  true,
+		},
+		{
+			name: "generic accepts all",
+			in:   "INSERT INTO t (a) VALUES (1::int) ON DUPLICATE KEY UPDATE a = 2",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt, err := Parse(c.in, c.opts...)
+			if c.err {
+				if err == nil {
+					t.Fatalf("must be error but parsed %s", stmt.ToSQLString())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+		})
+	}
+}
+
+func TestParse_Placeholders(t *testing.T) {
+	sqlite := Dialect(&dialect.SQLiteDialect{})
+
+	cases := []struct {
+		name  string
+		in    string
+		opts  []ParserOption
+		out   string
+		names []string
+	}{
+		{
+			name:  "question",
+			in:    "SELECT a FROM t WHERE b = ? AND c IN (?, ?) LIMIT ? OFFSET ?",
+			out:   "SELECT a FROM t WHERE b = ? AND c IN (?, ?) LIMIT ? OFFSET ?",
+			names: []string{"?", "?", "?", "?", "?"},
+		},
+		{
+			name:  "dollar",
+			in:    "UPDATE t SET a = $2 WHERE id = $1",
+			out:   "UPDATE t SET a = $2 WHERE id = $1",
+			names: []string{"$2", "$1"},
+		},
+		{
+			name:  "named",
+			in:    "INSERT INTO t (a, b) VALUES (:a, @b)",
+			out:   "INSERT INTO t (a, b) VALUES (:a, @b)",
+			names: []string{":a", "@b"},
+		},
+		{
+			name:  "source order",
+			in:    "SELECT * FROM a CROSS JOIN f(:x) WHERE a.id = :y",
+			out:   "SELECT * FROM a CROSS JOIN f(:x) WHERE a.id = :y",
+			names: []string{":x", ":y"},
+		},
+		{
+			name:  "numbered question in sqlite",
+			in:    "SELECT a FROM t WHERE b = ?1 AND c = ?12 AND d = ?",
+			opts:  []ParserOption{sqlite},
+			out:   "SELECT a FROM t WHERE b = ?1 AND c = ?12 AND d = ?",
+			names: []string{"?1", "?12", "?"},
+		},
+		{
+			name:  "named in sqlite",
+			in:    "SELECT a FROM t WHERE a = @p1 AND b = $p2 AND c = :p3",
+			opts:  []ParserOption{sqlite},
+			out:   "SELECT a FROM t WHERE a = @p1 AND b = $p2 AND c = :p3",
+			names: []string{"@p1", "$p2", ":p3"},
+		},
+		{
+			name:  "dollar quote and dollar name",
+			in:    "SELECT $tag$a$tag$ FROM t WHERE b = $name",
+			out:   "SELECT $tag$a$tag$ FROM t WHERE b = $name",
+			names: []string{"$name"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stmt, err := Parse(c.in, c.opts...)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if act := stmt.ToSQLString(); act != c.out {
+				t.Errorf("must be %s but %s", c.out, act)
+			}
+
+			var names []string
+			for _, p := range sqlast.Placeholders(stmt) {
+				names = append(names, p.ToSQLString())
+			}
+			if diff := cmp.Diff(c.names, names); diff != "" {
+				t.Errorf("diff %s", diff)
+			}
+		})
+	}
+}